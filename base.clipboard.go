@@ -0,0 +1,213 @@
+package myfyne
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const clipboardDefaultLimit = 50
+
+// TableFormat 表格数据复制到剪贴板时的格式
+type TableFormat int
+
+const (
+	TableTSV TableFormat = iota // 制表符分隔，可直接粘贴到 Excel 等表格软件
+	TableCSV
+)
+
+// ClipboardItem 剪贴板历史中的一条记录
+type ClipboardItem struct {
+	Text   string
+	Source string // 复制来源，例如窗口标题或控件名称
+	Time   time.Time
+}
+
+type clipboardService struct {
+	history []ClipboardItem
+	limit   int
+	mutex   sync.Mutex
+}
+
+var clipboard = &clipboardService{limit: clipboardDefaultLimit}
+
+// CopyFrom 复制 obj 相关的文本，通过 GetWindow(obj) 找到其所在窗口；source 为空时使用窗口标题
+func CopyFrom(obj fyne.CanvasObject, text, source string) {
+	if text == "" {
+		return
+	}
+
+	w := clipboardWindow(GetWindow(obj))
+	if w == nil {
+		return
+	}
+	if source == "" {
+		source = w.Title()
+	}
+	copyText(text, source)
+}
+
+// CopyTable 将表格数据按 TSV/CSV 格式复制到剪贴板，header 为空时不输出表头
+func CopyTable(w fyne.Window, header []string, rows [][]string, format TableFormat, source string) error {
+	text, err := FormatTable(header, rows, format)
+	if err != nil {
+		return err
+	}
+
+	if w = clipboardWindow(w); w == nil {
+		return errors.New("no window for clipboard")
+	}
+	if source == "" {
+		source = w.Title()
+	}
+	copyText(text, source)
+	return nil
+}
+
+// FormatTable 将表格数据格式化为 TSV/CSV 文本，字段中的分隔符、引号和换行会按 CSV 规则转义
+func FormatTable(header []string, rows [][]string, format TableFormat) (string, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if format == TableTSV {
+		writer.Comma = '\t'
+	}
+
+	if len(header) > 0 {
+		if err := writer.Write(header); err != nil {
+			return "", err
+		}
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// ClipboardHistory 剪贴板历史的副本，最新的在最前
+func ClipboardHistory() []ClipboardItem {
+	clipboard.mutex.Lock()
+	defer clipboard.mutex.Unlock()
+
+	return append([]ClipboardItem(nil), clipboard.history...)
+}
+
+// ClearClipboardHistory 清空剪贴板历史（不影响系统剪贴板）
+func ClearClipboardHistory() {
+	clipboard.mutex.Lock()
+	defer clipboard.mutex.Unlock()
+
+	clipboard.history = nil
+}
+
+// SetClipboardHistoryLimit 设置历史记录数量上限，默认 50
+func SetClipboardHistoryLimit(limit int) {
+	if limit <= 0 {
+		return
+	}
+
+	clipboard.mutex.Lock()
+	defer clipboard.mutex.Unlock()
+
+	clipboard.limit = limit
+	if len(clipboard.history) > limit {
+		clipboard.history = clipboard.history[:limit]
+	}
+}
+
+// ShowClipboardHistory 显示剪贴板历史，点击某条记录重新复制
+func ShowClipboardHistory(win fyne.Window) {
+	if win = clipboardWindow(win); win == nil {
+		return
+	}
+
+	items := ClipboardHistory()
+	var dlg *dialog.CustomDialog
+	list := widget.NewList(
+		func() int { return len(items) },
+		func() fyne.CanvasObject {
+			text := widget.NewLabel("")
+			text.Truncation = fyne.TextTruncateEllipsis
+			info := widget.NewLabel("")
+			info.Importance = widget.LowImportance
+			info.Truncation = fyne.TextTruncateEllipsis
+			return container.NewVBox(text, info)
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			box := obj.(*fyne.Container)
+			item := items[id]
+			box.Objects[0].(*widget.Label).SetText(strings.ReplaceAll(item.Text, "\n", " ⏎ "))
+			box.Objects[1].(*widget.Label).SetText(item.Time.Format("15:04:05") + "  " + item.Source)
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		item := items[id]
+		copyText(item.Text, item.Source)
+		dlg.Hide()
+		ShowToastWithOptions(win, "已复制", ToastOptions{Level: ToastSuccess, Duration: time.Second})
+	}
+
+	var body fyne.CanvasObject = list
+	if len(items) == 0 {
+		body = container.NewCenter(widget.NewLabel("暂无复制记录"))
+	}
+
+	dlg = dialog.NewCustom("剪贴板历史", "关闭", container.NewGridWrap(fyne.NewSize(360, 320), body), win)
+	done, _ := EnqueueDialog(win, dlg, "clipboard:history", DialogPriorityNormal, nil)
+	dlg.SetOnClosed(done)
+}
+
+// copyText 写入应用剪贴板（Window.Clipboard 已废弃）并记录历史
+func copyText(text, source string) {
+	fyne.CurrentApp().Clipboard().SetContent(text)
+	clipboard.record(ClipboardItem{Text: text, Source: source, Time: time.Now()})
+}
+
+// record 记录到历史，相同内容只保留最新的一条
+func (c *clipboardService) record(item ClipboardItem) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i, it := range c.history {
+		if it.Text == item.Text {
+			c.history = append(c.history[:i], c.history[i+1:]...)
+			break
+		}
+	}
+
+	c.history = append([]ClipboardItem{item}, c.history...)
+	if len(c.history) > c.limit {
+		c.history = c.history[:c.limit]
+	}
+}
+
+// clipboardWindow w 为 nil 时依次使用焦点页面的窗口、第一个窗口
+func clipboardWindow(w fyne.Window) fyne.Window {
+	if w != nil {
+		return w
+	}
+
+	wm := winManagerIns()
+	wm.mutex.Lock()
+	if page := wm.focusedPage(); page != nil {
+		w = wm.windows[wm.focusedID]
+	}
+	wm.mutex.Unlock()
+	if w != nil {
+		return w
+	}
+
+	if app := fyne.CurrentApp(); app != nil {
+		if wins := app.Driver().AllWindows(); len(wins) > 0 {
+			return wins[0]
+		}
+	}
+	return nil
+}
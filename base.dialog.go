@@ -1,50 +1,101 @@
 package myfyne
 
 import (
+	"context"
 	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
-	"fyne.io/fyne/v2/widget"
 )
 
+// ShowDialogWithCallback 显示自定义对话框；同一窗口已有对话框在显示时排队，前一个关闭后再显示
 func ShowDialogWithCallback(win fyne.Window, content DialogContent, onClose func(param any)) dialog.Dialog {
+	return showContentDialog(win, content.Content(), content, onClose)
+}
+
+func ShowDialogBySize(win fyne.Window, size fyne.Size, content DialogContent, onClose func(param any)) dialog.Dialog {
+	return showContentDialog(win, container.NewGridWrap(size, content.Content()), content, onClose)
+}
+
+func showContentDialog(win fyne.Window, obj fyne.CanvasObject, content DialogContent, onClose func(param any)) dialog.Dialog {
 	// 通过 content 的 Content() 方法获取展示的 fyne.CanvasObject
-	dlg := dialog.NewCustomWithoutButtons(content.Title(), content.Content(), win)
+	dlg := dialog.NewCustomWithoutButtons(content.Title(), obj, win)
 	// 设置 content 的 dialog 对象
 	content.SetDialog(dlg)
 	content.SetWindow(win)
+	// 由用户操作打开，立即显示；在其它对话框中打开时叠加在其上，避免等待父对话框关闭而无法显示
+	done, _ := EnqueueDialog(win, dlg, "", DialogPriorityHigh, nil)
 	dlg.SetOnClosed(func() {
 		if onClose != nil {
 			onClose(content.GetCloseParam()) // 通过回调传递关闭时的参数
 		}
+		done()
 	})
-	dlg.Show() // 显示 dialog
 	return dlg
 }
 
-func ShowDialogBySize(win fyne.Window, size fyne.Size, content DialogContent, onClose func(param any)) dialog.Dialog {
-	// 通过 content 的 Content() 方法获取展示的 fyne.CanvasObject
-	dlg := dialog.NewCustomWithoutButtons(content.Title(), container.NewGridWrap(size, content.Content()), win)
-	// 设置 content 的 dialog 对象
-	content.SetDialog(dlg)
-	content.SetWindow(win)
-	dlg.SetOnClosed(func() {
+// DialogResult 类型化的对话框关闭结果，Confirmed 为 false 表示取消（包括未传结果直接关闭）
+type DialogResult[T any] struct {
+	Value     T
+	Confirmed bool
+}
+
+// dialogResultOf 将 DialogContent.GetCloseParam 的返回值转换为 DialogResult[T]：
+// 参数为 DialogResult[T] 时原样返回；为 T 时视为确认；其它情况（包括 nil）视为取消
+func dialogResultOf[T any](param any) DialogResult[T] {
+	switch v := param.(type) {
+	case DialogResult[T]:
+		return v
+	case *DialogResult[T]:
+		if v != nil {
+			return *v
+		}
+	case T:
+		return DialogResult[T]{Value: v, Confirmed: true}
+	}
+	return DialogResult[T]{}
+}
+
+// ShowDialog 显示对话框，关闭时以 DialogResult[T] 回调，避免在回调中做类型断言
+func ShowDialog[T any](win fyne.Window, content DialogContent, onClose func(result DialogResult[T])) dialog.Dialog {
+	return ShowDialogWithCallback(win, content, func(param any) {
 		if onClose != nil {
-			onClose(content.GetCloseParam()) // 通过回调传递关闭时的参数
+			onClose(dialogResultOf[T](param))
 		}
 	})
-	dlg.Show() // 显示 dialog
-	return dlg
 }
 
+// ShowDialogChan 在主线程显示对话框并立即返回，对话框关闭时结果写入返回的 channel
+func ShowDialogChan[T any](win fyne.Window, content DialogContent) <-chan DialogResult[T] {
+	ch := make(chan DialogResult[T], 1)
+	RunOnMainAsync(func() {
+		ShowDialog[T](win, content, func(result DialogResult[T]) {
+			ch <- result
+			close(ch)
+		})
+	})
+	return ch
+}
+
+// AwaitDialog 显示对话框并阻塞等待结果，用于在 goroutine 中按顺序编写对话流程，不能在主线程中调用；
+// ctx 结束时关闭对话框并返回 ctx.Err()
+func AwaitDialog[T any](ctx context.Context, win fyne.Window, content DialogContent) (DialogResult[T], error) {
+	ch := ShowDialogChan[T](win, content)
+	select {
+	case result := <-ch:
+		return result, nil
+	case <-ctx.Done():
+		RunOnMainAsync(func() {
+			content.CloseDialog(nil)
+		})
+		return DialogResult[T]{}, ctx.Err()
+	}
+}
+
+// ShowToast 显示一条非模态的提示，duration 后自动消失
 func ShowToast(window fyne.Window, message string, duration time.Duration) {
-	content := widget.NewLabel(message)
-	dlg := dialog.NewCustomWithoutButtons("", container.NewCenter(content), window)
-	dlg.Show()
-	time.AfterFunc(duration, dlg.Hide)
-	return
+	ShowToastWithOptions(window, message, ToastOptions{Level: ToastInfo, Duration: duration})
 }
 
 func SendNotificationMsg(title, content string) {
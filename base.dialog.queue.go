@@ -0,0 +1,190 @@
+package myfyne
+
+import (
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+)
+
+// DialogPriority 对话框优先级：普通对话框排队依次显示；
+// 高优先级的对话框立即显示，有对话框正在显示时叠加在其上（当前对话框成为其父级），关闭后回到下层对话框
+type DialogPriority int
+
+const (
+	DialogPriorityNormal DialogPriority = iota
+	DialogPriorityHigh                  // 错误提示、在对话框中打开的子对话框等需要立即显示的对话框
+)
+
+type queuedDialog struct {
+	dlg      dialog.Dialog
+	key      string // 去重 key，为空时不去重
+	priority DialogPriority
+	onShown  func()
+}
+
+// dialogQueue 每个窗口一个队列：shown 为正在显示的对话框（后面的叠加在前面的之上），pending 为排队的普通对话框
+type dialogQueue struct {
+	shown   []*queuedDialog
+	pending []*queuedDialog
+}
+
+var (
+	dialogQueues = make(map[fyne.Window]*dialogQueue)
+	dialogMutex  sync.Mutex
+)
+
+// EnqueueDialog 将已创建但未显示的对话框加入窗口的队列。
+// 创建 dlg 时需在其 OnClosed 回调中调用返回的 done，队列才会显示下一个对话框；
+// key 不为空且相同 key 的对话框正在显示或排队时，不再入队并返回 false
+func EnqueueDialog(win fyne.Window, dlg dialog.Dialog, key string, priority DialogPriority, onShown func()) (done func(), ok bool) {
+	item := &queuedDialog{dlg: dlg, key: key, priority: priority, onShown: onShown}
+	done = func() {
+		dialogDone(win, item)
+	}
+
+	dialogMutex.Lock()
+	q, exists := dialogQueues[win]
+	if !exists {
+		q = &dialogQueue{}
+		dialogQueues[win] = q
+	}
+
+	if key != "" && q.contains(key) {
+		dialogMutex.Unlock()
+		return done, false
+	}
+
+	if len(q.shown) > 0 && priority == DialogPriorityNormal {
+		q.pending = append(q.pending, item)
+		dialogMutex.Unlock()
+		return done, true
+	}
+
+	q.shown = append(q.shown, item)
+	dialogMutex.Unlock()
+
+	item.show()
+	return done, true
+}
+
+// CurrentDialog 返回窗口最上层正在显示的对话框（通过队列显示的），没有时返回 nil
+func CurrentDialog(win fyne.Window) dialog.Dialog {
+	dialogMutex.Lock()
+	defer dialogMutex.Unlock()
+
+	if item := dialogQueues[win].top(); item != nil {
+		return item.dlg
+	}
+	return nil
+}
+
+// PendingDialogs 返回窗口中排队等待显示的对话框数量
+func PendingDialogs(win fyne.Window) int {
+	dialogMutex.Lock()
+	defer dialogMutex.Unlock()
+
+	if q, ok := dialogQueues[win]; ok {
+		return len(q.pending)
+	}
+	return 0
+}
+
+// dialogDone 对话框关闭：正在显示的则移除，没有对话框显示时显示下一个排队的；仍在排队的则移出队列
+func dialogDone(win fyne.Window, item *queuedDialog) {
+	dialogMutex.Lock()
+	q, ok := dialogQueues[win]
+	if !ok {
+		dialogMutex.Unlock()
+		return
+	}
+
+	if !q.removeShown(item) {
+		q.remove(item)
+		dialogMutex.Unlock()
+		return
+	}
+
+	var next *queuedDialog
+	if len(q.shown) == 0 {
+		if len(q.pending) > 0 {
+			next = q.pending[0]
+			q.pending = q.pending[1:]
+			q.shown = append(q.shown, next)
+		} else {
+			delete(dialogQueues, win)
+		}
+	}
+	dialogMutex.Unlock()
+
+	if next != nil {
+		next.show()
+	}
+}
+
+func (item *queuedDialog) show() {
+	item.dlg.Show()
+	if item.onShown != nil {
+		item.onShown()
+	}
+}
+
+// top 最上层正在显示的对话框，q 可以为 nil
+func (q *dialogQueue) top() *queuedDialog {
+	if q == nil || len(q.shown) == 0 {
+		return nil
+	}
+	return q.shown[len(q.shown)-1]
+}
+
+func (q *dialogQueue) contains(key string) bool {
+	for _, item := range q.shown {
+		if item.key == key {
+			return true
+		}
+	}
+
+	for _, item := range q.pending {
+		if item.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *dialogQueue) removeShown(item *queuedDialog) bool {
+	for i, p := range q.shown {
+		if p == item {
+			q.shown = append(q.shown[:i], q.shown[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (q *dialogQueue) remove(item *queuedDialog) {
+	for i, p := range q.pending {
+		if p == item {
+			q.pending = append(q.pending[:i], q.pending[i+1:]...)
+			return
+		}
+	}
+}
+
+// ShowErrorQueued 以高优先级立即显示错误（叠加在正在显示的对话框之上），相同内容的错误只显示一次
+func ShowErrorQueued(win fyne.Window, err error) {
+	if err == nil {
+		return
+	}
+
+	dlg := dialog.NewError(err, win)
+	done, _ := EnqueueDialog(win, dlg, "error:"+err.Error(), DialogPriorityHigh, nil)
+	dlg.SetOnClosed(done)
+}
+
+// ShowInfoQueued 排队显示提示信息，相同内容的提示只显示一次
+func ShowInfoQueued(win fyne.Window, title, message string) {
+	dlg := dialog.NewInformation(title, message, win)
+	done, _ := EnqueueDialog(win, dlg, "info:"+title+"\n"+message, DialogPriorityNormal, nil)
+	dlg.SetOnClosed(done)
+}
@@ -0,0 +1,194 @@
+package myfyne
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestDialogQueue(t *testing.T) {
+	SetApp(test.NewApp())
+	win := test.NewWindow(widget.NewLabel("queue"))
+	win.Resize(fyne.NewSize(400, 300))
+	defer win.Close()
+
+	ShowInfoQueued(win, "info", "first")
+	first := CurrentDialog(win)
+	ShowInfoQueued(win, "info", "first")
+	ShowInfoQueued(win, "info", "second")
+	ShowErrorQueued(win, errors.New("boom"))
+
+	if PendingDialogs(win) != 1 {
+		t.Fatalf("duplicate dialog should be dropped, pending=%d", PendingDialogs(win))
+	}
+	if dialogQueues[win].top().key != "error:boom" {
+		t.Fatal("error dialog should be shown on top of the current one")
+	}
+
+	CurrentDialog(win).Hide()
+	if CurrentDialog(win) != first || PendingDialogs(win) != 1 {
+		t.Fatal("closing the error should return to the dialog below it")
+	}
+
+	first.Hide()
+	CurrentDialog(win).Hide()
+	if CurrentDialog(win) != nil || PendingDialogs(win) != 0 {
+		t.Fatal("queue should be empty")
+	}
+}
+
+type nestedContent struct {
+	dlg dialog.Dialog
+}
+
+func (c *nestedContent) Title() string               { return "nested" }
+func (c *nestedContent) Content() fyne.CanvasObject  { return widget.NewLabel("nested") }
+func (c *nestedContent) SetDialog(dlg dialog.Dialog) { c.dlg = dlg }
+func (c *nestedContent) SetWindow(fyne.Window)       {}
+func (c *nestedContent) CloseDialog(any)             { c.dlg.Hide() }
+func (c *nestedContent) GetCloseParam() any          { return nil }
+
+func TestDialogQueue_Nested(t *testing.T) {
+	SetApp(test.NewApp())
+	win := test.NewWindow(widget.NewLabel("queue"))
+	win.Resize(fyne.NewSize(400, 300))
+	defer win.Close()
+
+	parent := ShowDialogWithCallback(win, &nestedContent{}, nil)
+	ShowInfoQueued(win, "info", "later")
+
+	var closed bool
+	child := ShowDialogWithCallback(win, &nestedContent{}, func(any) { closed = true })
+	if CurrentDialog(win) != child {
+		t.Fatal("dialog opened inside another dialog should be shown immediately")
+	}
+	ShowErrorQueued(win, errors.New("boom"))
+	if dialogQueues[win].top().key != "error:boom" {
+		t.Fatal("error raised inside a dialog should be shown immediately")
+	}
+
+	CurrentDialog(win).Hide()
+	child.Hide()
+	if !closed || CurrentDialog(win) != parent || PendingDialogs(win) != 1 {
+		t.Fatal("closing the child should return to its parent")
+	}
+
+	parent.Hide()
+	if CurrentDialog(win) == nil || PendingDialogs(win) != 0 {
+		t.Fatal("queued dialog should be shown after the stack is empty")
+	}
+	CurrentDialog(win).Hide()
+}
+
+func TestToast_Stacking(t *testing.T) {
+	SetApp(test.NewApp())
+	content := widget.NewLabel("toast")
+	win := test.NewWindow(content)
+	win.Resize(fyne.NewSize(400, 300))
+	defer win.Close()
+
+	ShowToastMsg(win, "saved", ToastSuccess)
+	ShowToastWithOptions(win, "deleted", ToastOptions{ActionText: "撤销", OnAction: func() {}})
+
+	host := toastHosts[win]
+	if host == nil || len(host.items) != 2 {
+		t.Fatal("toasts should stack on the window")
+	}
+	if GetWindow(content) != win {
+		t.Fatal("original content should still be reachable after toast layer is added")
+	}
+
+	host.items[0].MouseIn(nil)
+	if host.items[0].remaining <= 0 {
+		t.Fatal("hovering should pause the toast timer")
+	}
+
+	for len(host.items) > 0 {
+		host.remove(host.items[0])
+	}
+	if win.Content() != content || toastHosts[win] != nil {
+		t.Fatal("window content should be restored after the last toast is removed")
+	}
+}
+
+func TestRunWithProgress_Panic(t *testing.T) {
+	SetApp(test.NewApp())
+	win := test.NewWindow(widget.NewLabel("progress"))
+	win.Resize(fyne.NewSize(400, 300))
+	defer win.Close()
+
+	result := make(chan error, 1)
+	RunWithProgress(win, "working", func(ctx context.Context, report ProgressReporter) error {
+		report(0.5, "half way")
+		panic("boom")
+	}, func(err error) {
+		result <- err
+	})
+
+	if err := <-result; err == nil {
+		t.Fatal("panic should be reported as error")
+	}
+}
+
+func TestRunWithProgress_InDialog(t *testing.T) {
+	SetApp(test.NewApp())
+	win := test.NewWindow(widget.NewLabel("progress"))
+	win.Resize(fyne.NewSize(400, 300))
+	defer win.Close()
+
+	parent := ShowDialogWithCallback(win, &nestedContent{}, nil)
+	defer parent.Hide()
+
+	release := make(chan struct{})
+	result := make(chan error, 1)
+	RunWithProgress(win, "working", func(ctx context.Context, report ProgressReporter) error {
+		<-release
+		return nil
+	}, func(err error) {
+		result <- err
+	})
+
+	if CurrentDialog(win) == parent || CurrentDialog(win) == nil {
+		t.Fatal("progress started inside a dialog should be shown immediately")
+	}
+
+	close(release)
+	if err := <-result; err != nil {
+		t.Fatal(err)
+	}
+	if CurrentDialog(win) != parent {
+		t.Fatal("closing the progress should return to the parent dialog")
+	}
+}
+
+func TestClipboardHistory(t *testing.T) {
+	SetApp(test.NewApp())
+	label := widget.NewLabel("copy")
+	win := test.NewWindow(container.NewVBox(label))
+	win.SetTitle("clipboard")
+	defer win.Close()
+	ClearClipboardHistory()
+
+	CopyFrom(label, "hello", "")
+	CopyToClipboard(win, "world")
+	CopyFrom(label, "hello", "label")
+	if got := fyne.CurrentApp().Clipboard().Content(); got != "hello" {
+		t.Fatalf("clipboard = %q", got)
+	}
+
+	history := ClipboardHistory()
+	if len(history) != 2 || history[0].Text != "hello" || history[0].Source != "label" {
+		t.Fatalf("history = %+v", history)
+	}
+
+	text, err := FormatTable([]string{"name", "note"}, [][]string{{"a", "x\ty"}}, TableTSV)
+	if err != nil || text != "name\tnote\na\t\"x\ty\"" {
+		t.Fatalf("tsv = %q, %v", text, err)
+	}
+}
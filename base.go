@@ -19,6 +19,73 @@ type (
 		WinClosed()
 	}
 
+	// PageShow 可选接口：页面窗口显示时调用
+	PageShow interface {
+		OnShow()
+	}
+
+	// PageHide 可选接口：页面窗口隐藏或关闭时调用
+	PageHide interface {
+		OnHide()
+	}
+
+	// PageFocus 可选接口：页面窗口获得/失去焦点时调用
+	// fyne 没有窗口级的焦点事件，窗口管理器根据 ShowPage/ShowWindow 等调用以及应用前后台切换来推算
+	PageFocus interface {
+		OnFocus()
+		OnBlur()
+	}
+
+	// PageBeforeClose 可选接口：窗口关闭前调用，返回 false 阻止关闭
+	PageBeforeClose interface {
+		OnBeforeClose() bool
+	}
+
+	// PageUnsaved 可选接口：退出应用时，返回 true 的页面会列在未保存提示对话框中
+	PageUnsaved interface {
+		HasUnsavedChanges() bool
+	}
+
+	// PageTray 可选接口：启用托盘后，返回 true 的页面关闭窗口时收起到托盘而不是销毁
+	PageTray interface {
+		HideToTray() bool
+	}
+
+	// PageResize 可选接口：窗口内容区域尺寸变化时调用
+	PageResize interface {
+		OnResize(size fyne.Size)
+	}
+
+	// PageAppear 可选接口：页面成为导航栈顶（可见）时调用
+	PageAppear interface {
+		OnAppear()
+	}
+
+	// PageDisappear 可选接口：页面离开导航栈顶（被覆盖或出栈）时调用
+	PageDisappear interface {
+		OnDisappear()
+	}
+
+	// PageGeometry 可选接口：返回 false 时窗口管理器不记忆/恢复该页面窗口的尺寸
+	PageGeometry interface {
+		RememberGeometry() bool
+	}
+
+	// MultiInstancePage 可选接口：同一类页面（相同类型与 WinID）可按 InstanceKey 同时打开多个窗口，
+	// 窗口管理器为每个实例分配独立的窗口 id（ListPages 中可见）；MaxInstances <= 0 表示不限制数量。
+	// 注意：fyne 没有设置窗口位置的接口，新实例窗口默认按 centerOnScreen 居中，
+	// 需要层叠摆放时通过 SetInstancePlacement 提供平台相关的实现
+	MultiInstancePage interface {
+		Page
+		InstanceKey() string
+		MaxInstances() int
+	}
+
+	// PageResult 可选接口：页面关闭时返回的结果，用于模态页面回传数据
+	PageResult interface {
+		GetCloseParam() any
+	}
+
 	//自定义对话框
 	DialogContent interface {
 		Title() string
@@ -29,6 +96,7 @@ type (
 		GetCloseParam() any          // 获取关闭时的参数
 	}
 
+	// WinWillCloseFn 窗口关闭前的拦截函数，返回 false 阻止关闭；新代码建议让页面实现 PageBeforeClose
 	WinWillCloseFn func() bool
 	// MenuItem 定义菜单项的结构
 	MenuItemModel struct {
@@ -82,8 +150,20 @@ const (
 	Infinity float32 = math.MaxFloat32 //代表无穷大，一般表示可以尽可能的占用父类的空间
 )
 
+// GetWindow 查找 obj 所在的窗口，优先按 obj 所在的画布匹配，找不到时遍历窗口内容
 func GetWindow(obj fyne.CanvasObject) fyne.Window {
+	if obj == nil || fyne.CurrentApp() == nil {
+		return nil
+	}
+
 	listWindow := fyne.CurrentApp().Driver().AllWindows()
+	if c := fyne.CurrentApp().Driver().CanvasForObject(obj); c != nil {
+		for _, win := range listWindow {
+			if win.Canvas() == c {
+				return win
+			}
+		}
+	}
 
 	for _, win := range listWindow {
 		if containsObject(win.Content(), obj) {
@@ -0,0 +1,93 @@
+package myfyne
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ProgressReporter 上报任务进度，可在任意 goroutine 中调用；
+// value 取值 0~1，小于 0 时显示不确定进度；message 为空时不更新提示文字
+type ProgressReporter func(value float64, message string)
+
+// RunWithProgress 在 goroutine 中执行 task 并显示进度对话框，点击取消时 ctx 被取消。
+// 无论 task 成功、返回错误还是 panic，对话框都会关闭；返回错误（取消除外）或 panic 时显示错误。
+// onDone 在主线程回调，err 为 task 的返回值（panic 时为包装后的错误）；返回的函数可用于在外部取消任务。
+// 进度对话框不排队，在其它对话框中调用时叠加在其上立即显示
+func RunWithProgress(win fyne.Window, title string, task func(ctx context.Context, report ProgressReporter) error, onDone func(err error)) context.CancelFunc {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	message := widget.NewLabel("")
+	message.Hide()
+	bar := widget.NewProgressBar()
+	bar.Hide()
+	infinite := widget.NewProgressBarInfinite()
+
+	var cancelBtn *widget.Button
+	cancelBtn = widget.NewButton("取消", func() {
+		cancelBtn.SetText("正在取消…")
+		cancelBtn.Disable()
+		cancel()
+	})
+
+	body := container.NewVBox(message, container.NewStack(bar, infinite), container.NewCenter(cancelBtn))
+	dlg := dialog.NewCustomWithoutButtons(title, container.NewGridWrap(fyne.NewSize(320, body.MinSize().Height), body), win)
+	// 高优先级立即显示，避免在对话框中调用时等待父对话框关闭
+	done, _ := EnqueueDialog(win, dlg, "", DialogPriorityHigh, nil)
+	dlg.SetOnClosed(done)
+
+	report := func(value float64, msg string) {
+		RunOnMainAsync(func() {
+			if msg != "" {
+				message.SetText(msg)
+				message.Show()
+			}
+
+			if value < 0 {
+				bar.Hide()
+				infinite.Start()
+				infinite.Show()
+			} else {
+				infinite.Stop()
+				infinite.Hide()
+				bar.SetValue(value)
+				bar.Show()
+			}
+		})
+	}
+
+	go func() {
+		err := runTask(ctx, task, report)
+		cancel()
+
+		RunOnMainAsync(func() {
+			infinite.Stop()
+			dlg.Hide()
+			if err != nil && !errors.Is(err, context.Canceled) {
+				ShowErrorQueued(win, err)
+			}
+
+			if onDone != nil {
+				onDone(err)
+			}
+		})
+	}()
+
+	return cancel
+}
+
+// runTask 执行任务，将 panic 转换为错误
+func runTask(ctx context.Context, task func(ctx context.Context, report ProgressReporter) error, report ProgressReporter) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("task panic: %v", r)
+		}
+	}()
+
+	return task(ctx, report)
+}
@@ -0,0 +1,149 @@
+package myfyne
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"fyne.io/fyne/v2"
+)
+
+const (
+	installKeyFile   = "myfyne.install.key"
+	encryptedMagic   = "MFE1"
+	encryptedSaltLen = 16
+	encryptedKeyLen  = 32
+	pbkdf2Iterations = 200000
+)
+
+var (
+	// ErrEncryptedFileInvalid 文件不是加密格式或已截断
+	ErrEncryptedFileInvalid = errors.New("invalid encrypted file")
+	// ErrEncryptedFileTampered 文件内容被篡改，或口令/安装密钥不正确
+	ErrEncryptedFileTampered = errors.New("encrypted file has been tampered with or the key is wrong")
+
+	installKeyMutex sync.Mutex
+)
+
+// SaveToEncryptedLocFile 将 obj 序列化为 JSON 后以 AES-256-GCM 加密保存到应用存储目录。
+// passphrase 不为空时通过 PBKDF2 从口令派生密钥；为空时使用保存在应用存储中的本机安装密钥（首次使用时生成）
+func SaveToEncryptedLocFile(app fyne.App, fileName string, obj any, passphrase string) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	salt := make([]byte, encryptedSaltLen)
+	if _, err = rand.Read(salt); err != nil {
+		return err
+	}
+
+	gcm, err := newFileCipher(app, passphrase, salt)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return err
+	}
+
+	// 文件格式：magic | salt | nonce | 密文，magic 与 salt 作为附加数据参与认证
+	header := append([]byte(encryptedMagic), salt...)
+	out := append(append(header, nonce...), gcm.Seal(nil, nonce, data, header)...)
+	return os.WriteFile(GetLocFile(app, fileName), out, 0600)
+}
+
+// LoadFromEncryptedLocFile 读取 SaveToEncryptedLocFile 保存的文件；
+// 格式错误返回 ErrEncryptedFileInvalid，校验失败（被篡改或密钥不对）返回 ErrEncryptedFileTampered
+func LoadFromEncryptedLocFile[T any](app fyne.App, fileName string, passphrase string) (*T, error) {
+	raw, err := os.ReadFile(GetLocFile(app, fileName))
+	if err != nil {
+		return nil, err
+	}
+
+	headerLen := len(encryptedMagic) + encryptedSaltLen
+	if len(raw) < headerLen || !bytes.Equal(raw[:len(encryptedMagic)], []byte(encryptedMagic)) {
+		return nil, ErrEncryptedFileInvalid
+	}
+
+	header := raw[:headerLen]
+	gcm, err := newFileCipher(app, passphrase, header[len(encryptedMagic):])
+	if err != nil {
+		return nil, err
+	}
+
+	body := raw[headerLen:]
+	if len(body) < gcm.NonceSize()+gcm.Overhead() {
+		return nil, ErrEncryptedFileInvalid
+	}
+
+	nonce, sealed := body[:gcm.NonceSize()], body[gcm.NonceSize():]
+	data, err := gcm.Open(nil, nonce, sealed, header)
+	if err != nil {
+		return nil, ErrEncryptedFileTampered
+	}
+
+	var obj T
+	if err = json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	return &obj, nil
+}
+
+func newFileCipher(app fyne.App, passphrase string, salt []byte) (cipher.AEAD, error) {
+	var key []byte
+	var err error
+	if passphrase != "" {
+		key, err = pbkdf2.Key(sha256.New, passphrase, salt, pbkdf2Iterations, encryptedKeyLen)
+	} else {
+		key, err = installKey(app)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// installKey 读取本机安装密钥，不存在时生成并保存到应用存储目录
+func installKey(app fyne.App) ([]byte, error) {
+	installKeyMutex.Lock()
+	defer installKeyMutex.Unlock()
+
+	file := GetLocFile(app, installKeyFile)
+	key, err := os.ReadFile(file)
+	if err == nil {
+		if len(key) != encryptedKeyLen {
+			return nil, fmt.Errorf("invalid install key: %s", file)
+		}
+		return key, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	key = make([]byte, encryptedKeyLen)
+	if _, err = rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(app.Storage().RootURI().Path(), 0700); err != nil {
+		return nil, err
+	}
+	if err = os.WriteFile(file, key, 0600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
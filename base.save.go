@@ -23,8 +23,8 @@ func LoadFromLocFile[T any](app fyne.App, fileName string) (*T, error) {
 	return &creds, err
 }
 
+// SaveToLocFile 以明文 JSON 保存，密码等敏感数据请使用 SaveToEncryptedLocFile
 func SaveToLocFile(app fyne.App, fileName string, obj any) error {
-	// 示例中未加密，生产环境应加密 password
 	data, err := json.Marshal(obj)
 	if err != nil {
 		return err
@@ -0,0 +1,288 @@
+package myfyne
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// 以本地文件方式保存的设置统一写入该文件
+const settingsFile = "myfyne.settings.json"
+
+// SettingStorage 设置的持久化方式
+type SettingStorage int
+
+const (
+	SettingStoragePreferences SettingStorage = iota // fyne.Preferences（默认）
+	SettingStorageFile                              // 应用存储目录下的 myfyne.settings.json
+)
+
+// SettingType 支持的设置类型
+type SettingType interface {
+	bool | int | float64 | string
+}
+
+// SettingOptions 设置的描述信息，用于生成设置界面
+type SettingOptions struct {
+	Section string // 分组，为空时归入 "通用"
+	Label   string // 显示名称，为空时使用 key
+	Hint    string
+	Order   int // 同一分组内按 Order 升序，相同时按注册顺序
+	Storage SettingStorage
+	Choices []string // string 类型可选值，不为空时显示为下拉框
+	Min     float64  // int/float64 类型 Max > Min 时显示为滑块
+	Max     float64
+	Step    float64
+}
+
+// SettingEntry 已注册设置的通用描述，DataItem 的实际类型为 binding.Bool/Int/Float/String
+type SettingEntry interface {
+	Key() string
+	Options() SettingOptions
+	DataItem() binding.DataItem
+	Reset()
+}
+
+// Setting 类型化的设置项，所有窗口共享同一个数据绑定，修改后自动持久化并实时通知绑定的控件
+type Setting[T SettingType] struct {
+	key  string
+	def  T
+	opts SettingOptions
+	item binding.Item[T]
+	once sync.Once
+}
+
+type settingsRegistry struct {
+	entries []SettingEntry
+	keys    map[string]SettingEntry
+	store   *SettingsStore[map[string]json.RawMessage]
+	values  map[string]json.RawMessage // 以文件方式保存的设置
+	mutex   sync.Mutex
+}
+
+var settings = &settingsRegistry{keys: make(map[string]SettingEntry)}
+
+// RegisterSetting 注册设置项，相同 key 重复注册时返回已注册的设置（类型不同时 panic）
+func RegisterSetting[T SettingType](key string, def T, opts SettingOptions) *Setting[T] {
+	settings.mutex.Lock()
+	defer settings.mutex.Unlock()
+
+	if e, ok := settings.keys[key]; ok {
+		s, ok := e.(*Setting[T])
+		if !ok {
+			panic(fmt.Sprintf("setting %q already registered with another type", key))
+		}
+		return s
+	}
+
+	if opts.Section == "" {
+		opts.Section = "通用"
+	}
+	if opts.Label == "" {
+		opts.Label = key
+	}
+
+	s := &Setting[T]{key: key, def: def, opts: opts}
+	settings.entries = append(settings.entries, s)
+	settings.keys[key] = s
+	return s
+}
+
+// RegisteredSettings 按分组（首次出现的顺序）与 Order 排序返回全部已注册的设置
+func RegisteredSettings() []SettingEntry {
+	settings.mutex.Lock()
+	entries := append([]SettingEntry(nil), settings.entries...)
+	settings.mutex.Unlock()
+
+	sections := make(map[string]int)
+	for _, e := range entries {
+		if _, ok := sections[e.Options().Section]; !ok {
+			sections[e.Options().Section] = len(sections)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].Options(), entries[j].Options()
+		if a.Section != b.Section {
+			return sections[a.Section] < sections[b.Section]
+		}
+		return a.Order < b.Order
+	})
+	return entries
+}
+
+// LookupSetting 按 key 查找已注册的设置
+func LookupSetting(key string) (SettingEntry, bool) {
+	settings.mutex.Lock()
+	defer settings.mutex.Unlock()
+
+	e, ok := settings.keys[key]
+	return e, ok
+}
+
+func (s *Setting[T]) Key() string {
+	return s.key
+}
+
+func (s *Setting[T]) Default() T {
+	return s.def
+}
+
+func (s *Setting[T]) Options() SettingOptions {
+	return s.opts
+}
+
+// Binding 类型化的数据绑定，例如 Setting[bool] 返回的即 binding.Bool
+func (s *Setting[T]) Binding() binding.Item[T] {
+	s.once.Do(s.init)
+	return s.item
+}
+
+func (s *Setting[T]) DataItem() binding.DataItem {
+	return s.Binding()
+}
+
+// Get 当前值
+func (s *Setting[T]) Get() T {
+	v, err := s.Binding().Get()
+	if err != nil {
+		return s.def
+	}
+	return v
+}
+
+// Set 修改并保存
+func (s *Setting[T]) Set(v T) {
+	_ = s.Binding().Set(v)
+}
+
+// Reset 恢复默认值
+func (s *Setting[T]) Reset() {
+	s.Set(s.def)
+}
+
+// init 首次使用时读取已保存的值，并在值变化时写回
+func (s *Setting[T]) init() {
+	s.item = binding.NewItem(func(a, b T) bool { return a == b })
+	_ = s.item.Set(s.load())
+
+	app := settingsApp()
+	if s.opts.Storage == SettingStoragePreferences && app != nil {
+		// 其它地方直接修改 Preferences 时同步到绑定
+		app.Preferences().AddChangeListener(func() {
+			_ = s.item.Set(s.load())
+		})
+	}
+
+	s.item.AddListener(binding.NewDataListener(func() {
+		v, err := s.item.Get()
+		if err == nil && v != s.load() {
+			s.save(v)
+		}
+	}))
+}
+
+func (s *Setting[T]) load() T {
+	app := settingsApp()
+	if app == nil {
+		return s.def
+	}
+
+	if s.opts.Storage == SettingStorageFile {
+		raw, ok := settings.fileValue(app, s.key)
+		if !ok {
+			return s.def
+		}
+		var v T
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return s.def
+		}
+		return v
+	}
+
+	var v any
+	p := app.Preferences()
+	switch def := any(s.def).(type) {
+	case bool:
+		v = p.BoolWithFallback(s.key, def)
+	case int:
+		v = p.IntWithFallback(s.key, def)
+	case float64:
+		v = p.FloatWithFallback(s.key, def)
+	case string:
+		v = p.StringWithFallback(s.key, def)
+	}
+	return v.(T)
+}
+
+func (s *Setting[T]) save(v T) {
+	app := settingsApp()
+	if app == nil {
+		return
+	}
+
+	if s.opts.Storage == SettingStorageFile {
+		raw, err := json.Marshal(v)
+		if err == nil {
+			settings.setFileValue(app, s.key, raw)
+		}
+		return
+	}
+
+	p := app.Preferences()
+	switch val := any(v).(type) {
+	case bool:
+		p.SetBool(s.key, val)
+	case int:
+		p.SetInt(s.key, val)
+	case float64:
+		p.SetFloat(s.key, val)
+	case string:
+		p.SetString(s.key, val)
+	}
+}
+
+func settingsApp() fyne.App {
+	if app := GetApp(); app != nil {
+		return app
+	}
+	return fyne.CurrentApp()
+}
+
+func (r *settingsRegistry) fileValue(app fyne.App, key string) (json.RawMessage, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.ensureFile(app)
+	raw, ok := r.values[key]
+	return raw, ok
+}
+
+func (r *settingsRegistry) setFileValue(app fyne.App, key string, raw json.RawMessage) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.ensureFile(app)
+	r.values[key] = raw
+	_ = r.store.Save(&r.values)
+}
+
+// ensureFile 调用方需持有 r.mutex
+func (r *settingsRegistry) ensureFile(app fyne.App) {
+	if r.store != nil {
+		return
+	}
+
+	r.store = NewSettingsStore(app, settingsFile, 1, func() map[string]json.RawMessage {
+		return make(map[string]json.RawMessage)
+	})
+	if values, err := r.store.Load(); err == nil && *values != nil {
+		r.values = *values
+	} else {
+		r.values = make(map[string]json.RawMessage)
+	}
+}
@@ -0,0 +1,270 @@
+package myfyne
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"fyne.io/fyne/v2"
+)
+
+var (
+	// ErrSettingsTooNew 设置文件由更高版本的程序写入，为避免丢失数据不做恢复
+	ErrSettingsTooNew = errors.New("settings file is newer than the current version")
+	// ErrSettingsMigration 迁移函数返回错误，文件保持原样，不做恢复
+	ErrSettingsMigration = errors.New("settings migration failed")
+)
+
+// SettingsMigration 将 from 版本的数据升级到 from+1 版本；只有数据为 JSON 对象（T 为结构体或映射）时才能使用迁移
+type SettingsMigration func(data map[string]any) (map[string]any, error)
+
+// settingsEnvelope 设置文件格式，数据与 schema 版本一起保存
+type settingsEnvelope struct {
+	Version int             `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// SettingsStore 带版本号的设置存储：
+//   - 读取旧版本文件时依次执行已注册的迁移函数；
+//   - 写入时先写临时文件再重命名，并将上一次的文件保留为 .bak；
+//   - 文件损坏时依次尝试 .bak 与默认值，损坏的文件另存为 .corrupt。
+//
+// T 可以是任意能用 JSON 编码的类型；没有版本信息的文件（例如之前由 SaveToLocFile 保存的）按版本 0 处理
+type SettingsStore[T any] struct {
+	app        fyne.App
+	fileName   string
+	version    int
+	defaults   func() T
+	migrations map[int]SettingsMigration
+	onCorrupt  func(err error)
+	mutex      sync.Mutex
+}
+
+// NewSettingsStore 创建设置存储，version 为当前 schema 版本；defaults 返回默认设置，为 nil 时使用零值
+func NewSettingsStore[T any](app fyne.App, fileName string, version int, defaults func() T) *SettingsStore[T] {
+	if defaults == nil {
+		defaults = func() T {
+			var v T
+			return v
+		}
+	}
+
+	return &SettingsStore[T]{
+		app:        app,
+		fileName:   fileName,
+		version:    version,
+		defaults:   defaults,
+		migrations: make(map[int]SettingsMigration),
+	}
+}
+
+// RegisterMigration 注册从 from 版本升级到 from+1 版本的迁移函数；未注册的版本数据原样升级
+func (s *SettingsStore[T]) RegisterMigration(from int, fn SettingsMigration) *SettingsStore[T] {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.migrations[from] = fn
+	return s
+}
+
+// SetOnCorrupt 设置文件损坏时的回调，可用于记录日志或提示用户
+func (s *SettingsStore[T]) SetOnCorrupt(fn func(err error)) *SettingsStore[T] {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.onCorrupt = fn
+	return s
+}
+
+// Version 当前 schema 版本
+func (s *SettingsStore[T]) Version() int {
+	return s.version
+}
+
+// Load 读取设置。文件不存在时返回默认值；文件损坏时从备份或默认值恢复，并通过 SetOnCorrupt 的回调通知。
+// 执行过迁移或从备份恢复后会立即以当前版本写回；文件版本高于当前版本时返回 ErrSettingsTooNew，
+// 迁移失败时返回 ErrSettingsMigration，这两种情况都不会修改文件
+func (s *SettingsStore[T]) Load() (*T, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	file := GetLocFile(s.app, s.fileName)
+	v, migrated, err := s.loadFile(file)
+	if err == nil {
+		if migrated {
+			return v, s.save(v)
+		}
+		return v, nil
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		return s.newDefault(), nil
+	}
+
+	var readErr *os.PathError
+	if errors.As(err, &readErr) || errors.Is(err, ErrSettingsTooNew) || errors.Is(err, ErrSettingsMigration) {
+		return nil, err
+	}
+
+	s.corrupted(file, err)
+	if v, _, err = s.loadFile(file + ".bak"); err != nil {
+		v = s.newDefault()
+	}
+	return v, s.save(v)
+}
+
+// Save 以当前版本原子写入设置
+func (s *SettingsStore[T]) Save(v *T) error {
+	if v == nil {
+		return errors.New("settings is nil")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.save(v)
+}
+
+// Reset 恢复为默认设置并保存
+func (s *SettingsStore[T]) Reset() (*T, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	v := s.newDefault()
+	return v, s.save(v)
+}
+
+// loadFile 读取并迁移数据，migrated 表示文件版本低于当前版本
+func (s *SettingsStore[T]) loadFile(file string) (v *T, migrated bool, err error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, false, err
+	}
+
+	version, data, err := decodeSettingsEnvelope(raw)
+	if err != nil {
+		return nil, false, err
+	}
+	if version > s.version {
+		return nil, false, fmt.Errorf("%w: %d > %d", ErrSettingsTooNew, version, s.version)
+	}
+
+	for ; version < s.version; version++ {
+		migrated = true
+		fn, ok := s.migrations[version]
+		if !ok {
+			continue
+		}
+		if data, err = migrateSettings(data, fn); err != nil {
+			return nil, false, fmt.Errorf("%w from version %d: %w", ErrSettingsMigration, version, err)
+		}
+	}
+
+	// 在默认值的基础上解码，结构体、映射新增的字段保持默认值
+	v = s.newDefault()
+	if err = json.Unmarshal(data, v); err != nil {
+		return nil, false, err
+	}
+	return v, migrated, nil
+}
+
+// migrateSettings 以 map 形式执行迁移函数
+func migrateSettings(data json.RawMessage, fn SettingsMigration) (json.RawMessage, error) {
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("settings data is not an object: %w", err)
+	}
+	if fields == nil {
+		fields = make(map[string]any)
+	}
+
+	fields, err := fn(fields)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+func (s *SettingsStore[T]) save(v *T) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	raw, err := json.MarshalIndent(settingsEnvelope{Version: s.version, Data: data}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	file := GetLocFile(s.app, s.fileName)
+	if err = os.MkdirAll(s.app.Storage().RootURI().Path(), 0700); err != nil {
+		return err
+	}
+
+	tmp := file + ".tmp"
+	if err = writeFileSync(tmp, raw); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	if old, err := os.ReadFile(file); err == nil {
+		if _, _, err = decodeSettingsEnvelope(old); err == nil {
+			_ = writeFileSync(file+".bak", old)
+		}
+	}
+	return os.Rename(tmp, file)
+}
+
+func (s *SettingsStore[T]) newDefault() *T {
+	v := s.defaults()
+	return &v
+}
+
+// corrupted 保留损坏的文件以便排查，并通知调用方
+func (s *SettingsStore[T]) corrupted(file string, err error) {
+	_ = os.Rename(file, file+".corrupt")
+	if s.onCorrupt != nil {
+		s.onCorrupt(fmt.Errorf("settings file %s is corrupt: %w", s.fileName, err))
+	}
+}
+
+// decodeSettingsEnvelope 解析设置文件，没有版本信息的 JSON（任意类型）按版本 0 处理
+func decodeSettingsEnvelope(raw []byte) (int, json.RawMessage, error) {
+	if !json.Valid(raw) {
+		return 0, nil, errors.New("settings file is not valid JSON")
+	}
+
+	// 只有恰好包含 version 与 data 两个键的对象才是带版本的文件
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return 0, raw, nil
+	}
+
+	v, hasVersion := fields["version"]
+	data, hasData := fields["data"]
+	if !hasVersion || !hasData || len(fields) != 2 {
+		return 0, raw, nil
+	}
+
+	version := 0
+	if err := json.Unmarshal(v, &version); err != nil {
+		return 0, nil, err
+	}
+	return version, data, nil
+}
+
+func writeFileSync(file string, data []byte) error {
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
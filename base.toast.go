@@ -0,0 +1,360 @@
+package myfyne
+
+import (
+	"image/color"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ToastLevel 提示级别，决定图标与边框颜色
+type ToastLevel int
+
+const (
+	ToastInfo ToastLevel = iota
+	ToastSuccess
+	ToastWarning
+	ToastError
+)
+
+const (
+	toastDefaultDuration = 3 * time.Second
+	toastFadeDuration    = 200 * time.Millisecond
+	toastMaxCount        = 5 // 同一窗口最多同时显示的 toast 数量，超出时最早的先关闭
+)
+
+// ToastOptions 显示 toast 的参数
+type ToastOptions struct {
+	Level      ToastLevel
+	Duration   time.Duration // <=0 时默认 3 秒
+	ActionText string        // 不为空时显示操作按钮，例如 "撤销"
+	OnAction   func()        // 点击操作按钮后回调，toast 随即关闭
+}
+
+var (
+	toastHosts    = make(map[fyne.Window]*toastHost)
+	toastMutex    sync.Mutex
+	toastPosition = PositionBottomCenter
+)
+
+// SetToastPosition 设置 toast 在窗口中的显示位置，默认底部居中
+func SetToastPosition(position Position) {
+	toastMutex.Lock()
+	defer toastMutex.Unlock()
+
+	toastPosition = position
+	for _, host := range toastHosts {
+		host.layout.position = position
+		host.layer.Refresh()
+	}
+}
+
+// ShowToastMsg 显示一条非模态的 toast，多条 toast 会依次堆叠；鼠标悬停时暂停计时。
+// 注意：toast 显示期间窗口内容会被包裹在一个 Stack 中，Window.Content() 返回的是包裹后的容器，
+// 最后一条 toast 关闭后恢复为原内容，详见 toastHost
+func ShowToastMsg(win fyne.Window, message string, level ToastLevel) {
+	ShowToastWithOptions(win, message, ToastOptions{Level: level})
+}
+
+// ShowToastWithOptions 按参数显示 toast，可在任意 goroutine 中调用；对窗口内容的影响同 ShowToastMsg
+func ShowToastWithOptions(win fyne.Window, message string, opts ToastOptions) {
+	if win == nil || message == "" {
+		return
+	}
+
+	if opts.Duration <= 0 {
+		opts.Duration = toastDefaultDuration
+	}
+
+	RunOnMainAsync(func() {
+		host := toastHostFor(win)
+		if host == nil {
+			return
+		}
+		host.add(newToastItem(host, message, opts))
+	})
+}
+
+// toastHost 每个窗口一个 toast 层。
+// fyne 的 Overlays 会拦截整个窗口的输入，因此 toast 层以透明容器的形式叠放在窗口内容之上，
+// 空白区域不响应事件，点击可以穿透到下层内容。代价是显示 toast 时需要用 SetContent 替换窗口内容，
+// 所有 toast 关闭后再换回原内容
+type toastHost struct {
+	win     fyne.Window
+	content fyne.CanvasObject // 原窗口内容
+	root    *fyne.Container   // Stack(原窗口内容, layer)
+	layer   *fyne.Container
+	layout  *toastLayout
+	items   []*toastItem
+}
+
+// toastHostFor 获取窗口的 toast 层，窗口内容被替换过时重新包裹
+func toastHostFor(win fyne.Window) *toastHost {
+	toastMutex.Lock()
+	defer toastMutex.Unlock()
+
+	host, ok := toastHosts[win]
+	if ok && win.Content() == host.root {
+		return host
+	}
+
+	content := win.Content()
+	if content == nil {
+		return nil
+	}
+
+	host = &toastHost{win: win, content: content, layout: &toastLayout{position: toastPosition}}
+	host.layer = container.New(host.layout)
+	host.root = container.NewStack(content, host.layer)
+	win.SetContent(host.root)
+	toastHosts[win] = host
+	return host
+}
+
+func (h *toastHost) add(item *toastItem) {
+	h.items = append(h.items, item)
+	h.layer.Add(item)
+	item.start()
+
+	if len(h.items) > toastMaxCount {
+		h.items[0].dismiss()
+	}
+}
+
+func (h *toastHost) remove(item *toastItem) {
+	for i, it := range h.items {
+		if it == item {
+			h.items = append(h.items[:i], h.items[i+1:]...)
+			break
+		}
+	}
+	h.layer.Remove(item)
+
+	if len(h.items) == 0 {
+		toastMutex.Lock()
+		if toastHosts[h.win] == h {
+			delete(toastHosts, h.win)
+			if h.win.Content() == h.root {
+				h.win.SetContent(h.content)
+			}
+		}
+		toastMutex.Unlock()
+	}
+}
+
+// toastLayout 按 Position 将 toast 作为一个整体摆放，内部自上而下堆叠
+type toastLayout struct {
+	position Position
+}
+
+func (l *toastLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	pad := theme.Padding() * 2
+	area := fyne.NewSize(size.Width-pad*2, size.Height-pad*2)
+
+	var blockW, blockH float32
+	for _, o := range objects {
+		if !o.Visible() {
+			continue
+		}
+		ms := o.MinSize()
+		blockW = fyne.Max(blockW, fyne.Min(ms.Width, area.Width))
+		blockH += ms.Height + theme.Padding()
+	}
+	if blockH > 0 {
+		blockH -= theme.Padding()
+	}
+
+	origin := ChildPosition(l.position, area, fyne.NewSize(blockW, blockH)).AddXY(pad, pad)
+	y := origin.Y
+	for _, o := range objects {
+		if !o.Visible() {
+			continue
+		}
+		ms := o.MinSize()
+		w := fyne.Min(ms.Width, area.Width)
+
+		x := origin.X
+		switch l.position % 3 {
+		case 1: // 居中列
+			x += (blockW - w) / 2
+		case 2: // 右侧列
+			x += blockW - w
+		}
+
+		o.Resize(fyne.NewSize(w, ms.Height))
+		o.Move(fyne.NewPos(x, y))
+		y += ms.Height + theme.Padding()
+	}
+}
+
+// MinSize toast 层不影响窗口的最小尺寸
+func (l *toastLayout) MinSize(_ []fyne.CanvasObject) fyne.Size {
+	return fyne.NewSize(0, 0)
+}
+
+// toastItem 单条 toast
+type toastItem struct {
+	widget.BaseWidget
+	host      *toastHost
+	opts      ToastOptions
+	bg        *canvas.Rectangle
+	icon      *canvas.Image
+	text      *canvas.Text
+	action    *widget.Button
+	timer     *time.Timer
+	deadline  time.Time
+	remaining time.Duration
+	closing   bool
+}
+
+func newToastItem(host *toastHost, message string, opts ToastOptions) *toastItem {
+	t := &toastItem{host: host, opts: opts}
+
+	levelColor := opts.Level.ColorName()
+	t.bg = canvas.NewRectangle(theme.Color(theme.ColorNameOverlayBackground))
+	t.bg.CornerRadius = theme.InputRadiusSize()
+	t.bg.StrokeColor = theme.Color(levelColor)
+	t.bg.StrokeWidth = 1
+
+	t.icon = canvas.NewImageFromResource(theme.NewColoredResource(opts.Level.Icon(), levelColor))
+	t.icon.SetMinSize(fyne.NewSquareSize(theme.IconInlineSize()))
+	t.text = canvas.NewText(message, theme.Color(theme.ColorNameForeground))
+
+	if opts.ActionText != "" {
+		t.action = widget.NewButton(opts.ActionText, func() {
+			if t.opts.OnAction != nil {
+				t.opts.OnAction()
+			}
+			t.dismiss()
+		})
+		t.action.Importance = widget.LowImportance
+	}
+
+	t.ExtendBaseWidget(t)
+	return t
+}
+
+func (t *toastItem) CreateRenderer() fyne.WidgetRenderer {
+	row := container.NewHBox(t.icon, t.text)
+	if t.action != nil {
+		row.Add(t.action)
+	}
+	return widget.NewSimpleRenderer(container.NewStack(t.bg, container.NewPadded(row)))
+}
+
+// start 淡入并开始计时
+func (t *toastItem) start() {
+	t.setAlpha(0)
+	fyne.NewAnimation(toastFadeDuration, t.setAlpha).Start()
+	t.schedule(t.opts.Duration)
+}
+
+func (t *toastItem) schedule(d time.Duration) {
+	t.deadline = time.Now().Add(d)
+	t.timer = time.AfterFunc(d, func() {
+		fyne.Do(t.dismiss)
+	})
+}
+
+// dismiss 淡出后从 toast 层移除
+func (t *toastItem) dismiss() {
+	if t.closing {
+		return
+	}
+	t.closing = true
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+	if t.action != nil {
+		t.action.Hide()
+	}
+
+	fyne.NewAnimation(toastFadeDuration, func(p float32) {
+		t.setAlpha(1 - p)
+	}).Start()
+	time.AfterFunc(toastFadeDuration, func() {
+		fyne.Do(func() {
+			t.host.remove(t)
+		})
+	})
+}
+
+// MouseIn 鼠标悬停时暂停计时
+func (t *toastItem) MouseIn(_ *desktop.MouseEvent) {
+	if t.closing || t.timer == nil {
+		return
+	}
+	if t.timer.Stop() {
+		t.remaining = time.Until(t.deadline)
+	}
+}
+
+func (t *toastItem) MouseMoved(_ *desktop.MouseEvent) {}
+
+// MouseOut 鼠标离开后继续计时
+func (t *toastItem) MouseOut() {
+	if t.closing || t.remaining <= 0 {
+		return
+	}
+	t.schedule(t.remaining)
+	t.remaining = 0
+}
+
+// Tapped 点击 toast 立即关闭
+func (t *toastItem) Tapped(_ *fyne.PointEvent) {
+	t.dismiss()
+}
+
+func (t *toastItem) setAlpha(alpha float32) {
+	t.bg.FillColor = withAlpha(theme.Color(theme.ColorNameOverlayBackground), alpha)
+	t.bg.StrokeColor = withAlpha(theme.Color(t.opts.Level.ColorName()), alpha)
+	t.text.Color = withAlpha(theme.Color(theme.ColorNameForeground), alpha)
+	t.icon.Translucency = float64(1 - alpha)
+	t.bg.Refresh()
+	t.text.Refresh()
+	t.icon.Refresh()
+}
+
+func withAlpha(c color.Color, alpha float32) color.Color {
+	r, g, b, a := c.RGBA()
+	return color.NRGBA64{
+		R: uint16(r * 0xffff / max(a, 1)),
+		G: uint16(g * 0xffff / max(a, 1)),
+		B: uint16(b * 0xffff / max(a, 1)),
+		A: uint16(float32(a) * alpha),
+	}
+}
+
+// ColorName 级别对应的主题颜色
+func (level ToastLevel) ColorName() fyne.ThemeColorName {
+	switch level {
+	case ToastSuccess:
+		return theme.ColorNameSuccess
+	case ToastWarning:
+		return theme.ColorNameWarning
+	case ToastError:
+		return theme.ColorNameError
+	default:
+		return theme.ColorNamePrimary
+	}
+}
+
+// Icon 级别对应的图标
+func (level ToastLevel) Icon() fyne.Resource {
+	switch level {
+	case ToastSuccess:
+		return theme.ConfirmIcon()
+	case ToastWarning:
+		return theme.WarningIcon()
+	case ToastError:
+		return theme.ErrorIcon()
+	default:
+		return theme.InfoIcon()
+	}
+}
@@ -2,14 +2,14 @@ package myfyne
 
 import "fyne.io/fyne/v2"
 
+// CopyToClipboard 复制文本到窗口的剪贴板并记录到历史，w 为 nil 时使用当前焦点窗口
 func CopyToClipboard(w fyne.Window, text string) {
 	if text == "" {
 		return
 	}
 
-	if w == nil {
-		w = fyne.CurrentApp().Driver().AllWindows()[0]
+	if w = clipboardWindow(w); w == nil {
+		return
 	}
-
-	w.Clipboard().SetContent(text)
+	copyText(text, w.Title())
 }
@@ -0,0 +1,94 @@
+package myfyne
+
+import (
+	"sync"
+)
+
+type subscription struct {
+	handler func(msg any)
+	active  bool
+}
+
+// messageBus 窗口间通信的发布/订阅总线
+type messageBus struct {
+	subs  map[string][]*subscription
+	mutex sync.Mutex
+}
+
+var bus = &messageBus{subs: make(map[string][]*subscription)}
+
+func (b *messageBus) subscribe(topic string, handler func(msg any)) *subscription {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	sub := &subscription{handler: handler, active: true}
+	b.subs[topic] = append(b.subs[topic], sub)
+	return sub
+}
+
+func (b *messageBus) unsubscribe(topic string, sub *subscription) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	sub.active = false
+	list := b.subs[topic]
+	for i := range list {
+		if list[i] == sub {
+			b.subs[topic] = append(list[:i:i], list[i+1:]...)
+			break
+		}
+	}
+
+	if len(b.subs[topic]) == 0 {
+		delete(b.subs, topic)
+	}
+}
+
+func (b *messageBus) publish(topic string, msg any) {
+	b.mutex.Lock()
+	list := make([]*subscription, len(b.subs[topic]))
+	copy(list, b.subs[topic])
+	b.mutex.Unlock()
+
+	for _, sub := range list {
+		s := sub
+		RunOnMainAsync(func() {
+			//投递前再次确认订阅仍然有效，避免窗口关闭后仍然收到消息
+			b.mutex.Lock()
+			active := s.active
+			b.mutex.Unlock()
+
+			if active {
+				s.handler(msg)
+			}
+		})
+	}
+}
+
+// Subscribe 订阅主题，消息类型与 T 不一致时忽略；handler 在主线程执行
+// page 不为 nil 时，页面窗口关闭后自动取消订阅（按页面对象而不是窗口 id 关联，可在页面打开前订阅）；
+// 返回的函数可用于手动取消订阅
+func Subscribe[T any](page Page, topic string, handler func(msg T)) (unsubscribe func()) {
+	sub := bus.subscribe(topic, func(msg any) {
+		if v, ok := msg.(T); ok {
+			handler(v)
+		}
+	})
+
+	var once sync.Once
+	unsubscribe = func() {
+		once.Do(func() {
+			bus.unsubscribe(topic, sub)
+		})
+	}
+
+	if page != nil {
+		winManagerIns().addPageHook(page, unsubscribe)
+	}
+	return unsubscribe
+}
+
+// Publish 向主题发布消息，可在任意 goroutine 中调用
+func Publish[T any](topic string, msg T) {
+	bus.publish(topic, msg)
+}
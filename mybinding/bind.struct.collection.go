@@ -0,0 +1,461 @@
+package mybinding
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Time time.Time 字段的绑定
+type Time = binding.Item[time.Time]
+
+// NewTime 创建 time.Time 绑定，按 time.Equal 判断是否变化
+func NewTime() Time {
+	return binding.NewItem(func(a, b time.Time) bool { return a.Equal(b) })
+}
+
+// newCollectionItem 为切片/映射字段创建绑定：
+//   - 整数/浮点/字符串/布尔切片 -> binding.IntList/FloatList/StringList/BoolList；
+//   - 结构体（或结构体指针）切片 -> binding.UntypedList，元素为 *BindStruct[any]；
+//   - 键为字符串的映射 -> binding.UntypedMap。
+//
+// 其它类型返回 nil
+func (b *BindStruct[T]) newCollectionItem(path string, v reflect.Value) binding.DataItem {
+	if v.Kind() == reflect.Map {
+		if v.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		m := binding.NewUntypedMap()
+		m.Set(mapValues(v))
+		return m
+	}
+
+	switch elem := v.Type().Elem(); {
+	case isIntKind(elem.Kind()):
+		l := binding.NewIntList()
+		l.Set(intValues(v))
+		return l
+	case elem.Kind() == reflect.Float32 || elem.Kind() == reflect.Float64:
+		l := binding.NewFloatList()
+		l.Set(floatValues(v))
+		return l
+	case elem.Kind() == reflect.String:
+		l := binding.NewStringList()
+		l.Set(stringValues(v))
+		return l
+	case elem.Kind() == reflect.Bool:
+		l := binding.NewBoolList()
+		l.Set(boolValues(v))
+		return l
+	case isStructElem(elem):
+		l := binding.NewUntypedList()
+		items := make([]any, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, b.newNested(path, v.Index(i)))
+		}
+		l.Set(items)
+		return l
+	}
+	return nil
+}
+
+// bindCollection 保存集合绑定，集合或其元素变化时写回字段。
+// 回写是异步的（见 deferWriteBack），需要立即读取最新值时先调用 Flush
+func (b *BindStruct[T]) bindCollection(path string, item binding.DataItem) {
+	b.items[path] = item
+	item.AddListener(binding.NewDataListener(func() {
+		b.deferWriteBack(path, item)
+	}))
+}
+
+// deferWriteBack fyne 的列表/映射在持有内部锁时通知监听（例如 Set 更新已有的子项、Map.SetValue 新增键），
+// 此时同步读取集合会死锁，因此回写延后到主线程的下一轮执行
+func (b *BindStruct[T]) deferWriteBack(path string, item binding.DataItem) {
+	go fyne.Do(func() {
+		b.watchChildren(path, item)
+		b.writeBack(path, item)
+	})
+}
+
+// Flush 立即写回所有集合（切片/映射）绑定的修改。
+// 集合元素的修改（例如 list.Append、map.SetValue）是异步写回的，在此之前 Value、Changes、IsDirty
+// 以及变化监听都还是旧的状态；不能在集合绑定的监听回调中调用
+func (b *BindStruct[T]) Flush() {
+	b.mu.RLock()
+	collections := make(map[string]binding.DataItem)
+	var nested []*BindStruct[any]
+	for path, item := range b.items {
+		switch item.(type) {
+		case binding.DataList, binding.DataMap:
+			collections[path] = item
+		}
+	}
+	for _, children := range b.watched {
+		for child := range children {
+			if n, ok := child.(*BindStruct[any]); ok {
+				nested = append(nested, n)
+			}
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, n := range nested {
+		n.Flush()
+	}
+	for path, item := range collections {
+		b.watchChildren(path, item)
+		b.writeBack(path, item)
+	}
+}
+
+// watchChildren 同步集合元素的监听：监听新出现的元素（修改列表/映射的单个元素不会触发集合本身的监听），
+// 取消已被移除的元素的监听，避免它们一直被引用
+func (b *BindStruct[T]) watchChildren(path string, item binding.DataItem) {
+	var children []any
+	switch data := item.(type) {
+	case binding.DataList:
+		for i := 0; i < data.Length(); i++ {
+			if child, err := data.GetItem(i); err == nil {
+				children = append(children, child)
+			}
+		}
+		if l, ok := item.(binding.UntypedList); ok {
+			values, _ := l.Get()
+			for _, v := range values {
+				if n, ok := v.(*BindStruct[any]); ok {
+					children = append(children, n)
+				}
+			}
+		}
+	case binding.DataMap:
+		for _, key := range data.Keys() {
+			if child, err := data.GetItem(key); err == nil {
+				children = append(children, child)
+			}
+		}
+	}
+
+	b.mu.Lock()
+	watched := b.watched[path]
+	next := make(map[any]func(), len(children))
+	var added []any
+	for _, child := range children {
+		if remove, ok := watched[child]; ok {
+			next[child] = remove
+			delete(watched, child)
+		} else if _, ok := next[child]; !ok {
+			next[child] = nil
+			added = append(added, child)
+		}
+	}
+	b.watched[path] = next
+	b.mu.Unlock()
+
+	// watched 中剩下的是已被移除的元素
+	for _, remove := range watched {
+		if remove != nil {
+			remove()
+		}
+	}
+
+	for _, child := range added {
+		var remove func()
+		switch c := child.(type) {
+		case *BindStruct[any]:
+			remove = b.watchNested(path, c)
+		case binding.DataItem:
+			l := binding.NewDataListener(func() {
+				b.deferWriteBack(path, item)
+			})
+			c.AddListener(l)
+			remove = func() { c.RemoveListener(l) }
+		}
+		b.setWatched(path, child, remove)
+	}
+}
+
+// setWatched 记录元素取消监听的函数，元素在此期间已被移除时直接取消
+func (b *BindStruct[T]) setWatched(path string, child any, remove func()) {
+	b.mu.Lock()
+	_, ok := b.watched[path][child]
+	if ok {
+		b.watched[path][child] = remove
+	}
+	b.mu.Unlock()
+
+	if !ok && remove != nil {
+		remove()
+	}
+}
+
+// newNested 为结构体切片的元素创建嵌套的 BindStruct，nil 指针按零值处理
+func (b *BindStruct[T]) newNested(path string, elem reflect.Value) *BindStruct[any] {
+	n := NewBindStructEx[any](structValue(elem).Interface())
+	remove := b.watchNested(path, n)
+
+	b.mu.Lock()
+	if b.watched[path] == nil {
+		b.watched[path] = make(map[any]func())
+	}
+	b.watched[path][n] = remove
+	b.mu.Unlock()
+	return n
+}
+
+// watchNested 嵌套 BindStruct 的字段变化时写回切片，回调路径形如 "Items[1].Name"；返回的函数用于取消监听
+func (b *BindStruct[T]) watchNested(path string, n *BindStruct[any]) (remove func()) {
+	return n.AddChangeListener(func(sub string) {
+		b.mu.RLock()
+		item, ok := b.items[path].(binding.UntypedList)
+		b.mu.RUnlock()
+		if !ok || !b.writeField(path, item) {
+			return
+		}
+
+		changed := path
+		values, _ := item.Get()
+		for i, v := range values {
+			if v == n {
+				changed = fmt.Sprintf("%s[%d]", path, i)
+				break
+			}
+		}
+		if sub != "" {
+			changed += "." + sub
+		}
+		b.fireChanged(changed)
+	})
+}
+
+// collectionUpdate SetValue 时更新集合绑定，调用方需持有锁，返回的函数在锁外执行；
+// 结构体切片尽量复用已有的嵌套 BindStruct，只追加或截断长度变化的部分，更新后取消被移除元素的监听
+func (b *BindStruct[T]) collectionUpdate(path string, item binding.DataItem, v reflect.Value) func() {
+	update := b.collectionSet(path, item, v)
+	return func() {
+		update()
+		b.watchChildren(path, item)
+	}
+}
+
+// collectionSet 见 collectionUpdate
+func (b *BindStruct[T]) collectionSet(path string, item binding.DataItem, v reflect.Value) func() {
+	switch data := item.(type) {
+	case binding.IntList:
+		values := intValues(v)
+		return func() { data.Set(values) }
+	case binding.FloatList:
+		values := floatValues(v)
+		return func() { data.Set(values) }
+	case binding.StringList:
+		values := stringValues(v)
+		return func() { data.Set(values) }
+	case binding.BoolList:
+		values := boolValues(v)
+		return func() { data.Set(values) }
+	case binding.UntypedList:
+		elems := make([]reflect.Value, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			elems = append(elems, v.Index(i))
+		}
+		return func() {
+			current, _ := data.Get()
+			items := make([]any, 0, len(elems))
+			for i, elem := range elems {
+				if i < len(current) {
+					if n, ok := current[i].(*BindStruct[any]); ok {
+						n.SetValue(structValue(elem).Interface())
+						items = append(items, n)
+						continue
+					}
+				}
+				items = append(items, b.newNested(path, elem))
+			}
+			data.Set(items)
+		}
+	case binding.UntypedMap:
+		values := mapValues(v)
+		return func() { data.Set(values) }
+	}
+	return func() {}
+}
+
+// setCollectionField 将集合绑定或时间绑定的值写入字段，值没有变化时返回 false
+func setCollectionField(field reflect.Value, item binding.DataItem) bool {
+	var next reflect.Value
+	switch data := item.(type) {
+	case Time:
+		val, err := data.Get()
+		if err != nil || field.Type() != timeType || field.Interface().(time.Time).Equal(val) {
+			return false
+		}
+		field.Set(reflect.ValueOf(val))
+		return true
+	case binding.IntList:
+		values, _ := data.Get()
+		next = makeSlice(field, len(values), func(elem reflect.Value, i int) {
+			if isUintKind(elem.Kind()) {
+				elem.SetUint(uint64(values[i]))
+			} else {
+				elem.SetInt(int64(values[i]))
+			}
+		})
+	case binding.FloatList:
+		values, _ := data.Get()
+		next = makeSlice(field, len(values), func(elem reflect.Value, i int) { elem.SetFloat(values[i]) })
+	case binding.StringList:
+		values, _ := data.Get()
+		next = makeSlice(field, len(values), func(elem reflect.Value, i int) { elem.SetString(values[i]) })
+	case binding.BoolList:
+		values, _ := data.Get()
+		next = makeSlice(field, len(values), func(elem reflect.Value, i int) { elem.SetBool(values[i]) })
+	case binding.UntypedList:
+		values, _ := data.Get()
+		next = makeSlice(field, len(values), func(elem reflect.Value, i int) {
+			n, ok := values[i].(*BindStruct[any])
+			if !ok {
+				return
+			}
+			val := reflect.ValueOf(n.Value())
+			if elem.Kind() == reflect.Ptr {
+				if !val.Type().AssignableTo(elem.Type().Elem()) {
+					return
+				}
+				// 值没有变化的元素保留原指针（包括 nil），只为修改过的元素分配新指针
+				if i < field.Len() {
+					old := field.Index(i)
+					if old.IsNil() && val.IsZero() {
+						return
+					}
+					if !old.IsNil() && reflect.DeepEqual(old.Elem().Interface(), val.Interface()) {
+						elem.Set(old)
+						return
+					}
+				}
+				elem.Set(reflect.New(elem.Type().Elem()))
+				elem.Elem().Set(val)
+			} else if val.Type().AssignableTo(elem.Type()) {
+				elem.Set(val)
+			}
+		})
+	case binding.UntypedMap:
+		if field.Kind() != reflect.Map || field.Type().Key().Kind() != reflect.String {
+			return false
+		}
+		keys := data.Keys()
+		if len(keys) == 0 && field.Len() == 0 {
+			return false
+		}
+		next = reflect.MakeMapWithSize(field.Type(), len(keys))
+		elemType := field.Type().Elem()
+		for _, key := range keys {
+			val, err := data.GetValue(key)
+			if err != nil {
+				continue
+			}
+			rv := reflect.ValueOf(val)
+			if !rv.IsValid() {
+				rv = reflect.Zero(elemType)
+			} else if !rv.Type().ConvertibleTo(elemType) {
+				continue
+			}
+			next.SetMapIndex(reflect.ValueOf(key).Convert(field.Type().Key()), rv.Convert(elemType))
+		}
+	default:
+		return false
+	}
+
+	if !next.IsValid() || reflect.DeepEqual(field.Interface(), next.Interface()) {
+		return false
+	}
+	field.Set(next)
+	return true
+}
+
+// makeSlice 创建与字段同类型的切片，长度均为 0 时返回无效值（保持字段原样，避免 nil 与空切片来回切换）
+func makeSlice(field reflect.Value, n int, set func(elem reflect.Value, i int)) reflect.Value {
+	if field.Kind() != reflect.Slice || (n == 0 && field.Len() == 0) {
+		return reflect.Value{}
+	}
+
+	s := reflect.MakeSlice(field.Type(), n, n)
+	for i := 0; i < n; i++ {
+		set(s.Index(i), i)
+	}
+	return s
+}
+
+func intValues(v reflect.Value) []int {
+	values := make([]int, v.Len())
+	for i := range values {
+		if isUintKind(v.Index(i).Kind()) {
+			values[i] = int(v.Index(i).Uint())
+		} else {
+			values[i] = int(v.Index(i).Int())
+		}
+	}
+	return values
+}
+
+func floatValues(v reflect.Value) []float64 {
+	values := make([]float64, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Float()
+	}
+	return values
+}
+
+func stringValues(v reflect.Value) []string {
+	values := make([]string, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).String()
+	}
+	return values
+}
+
+func boolValues(v reflect.Value) []bool {
+	values := make([]bool, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Bool()
+	}
+	return values
+}
+
+func mapValues(v reflect.Value) map[string]any {
+	values := make(map[string]any, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		values[iter.Key().String()] = iter.Value().Interface()
+	}
+	return values
+}
+
+// structValue 结构体指针取其指向的值，nil 指针返回零值
+func structValue(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.New(v.Type().Elem()).Elem()
+		}
+		return v.Elem()
+	}
+	return v
+}
+
+func isStructElem(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t != timeType
+}
+
+func isIntKind(k reflect.Kind) bool {
+	return (k >= reflect.Int && k <= reflect.Int64) || isUintKind(k)
+}
+
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uint64
+}
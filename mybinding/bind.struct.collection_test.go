@@ -0,0 +1,95 @@
+package mybinding
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+)
+
+type testLine struct {
+	Name  string
+	Price float64
+}
+
+type testOrder struct {
+	Tags  []string
+	Lines []testLine
+	Extra map[string]int
+}
+
+func TestBindStruct_ListLength(t *testing.T) {
+	test.NewApp()
+
+	b := NewBindStructEx(testOrder{Tags: []string{"a"}})
+	var paths []string
+	b.AddChangeListener(func(path string) { paths = append(paths, path) })
+
+	item, _ := b.GetItem("Tags")
+	tags := item.(binding.StringList)
+	_ = tags.Append("b")
+	_ = tags.Append("c")
+	b.Flush()
+	if v := b.Value().Tags; len(v) != 3 || v[2] != "c" {
+		t.Fatalf("appended items not written back: %v", v)
+	}
+	if len(paths) == 0 || paths[len(paths)-1] != "Tags" {
+		t.Fatalf("unexpected changed paths: %v", paths)
+	}
+
+	_ = tags.SetValue(0, "z")
+	_ = tags.Remove("b")
+	b.Flush()
+	if v := b.Value().Tags; len(v) != 2 || v[0] != "z" || v[1] != "c" {
+		t.Fatalf("list edits not written back: %v", v)
+	}
+
+	extra, _ := b.GetItem("Extra")
+	_ = extra.(binding.UntypedMap).SetValue("qty", 2)
+	b.Flush()
+	if b.Value().Extra["qty"] != 2 {
+		t.Fatalf("map edits not written back: %v", b.Value().Extra)
+	}
+}
+
+func TestBindStruct_NestedList(t *testing.T) {
+	test.NewApp()
+
+	b := NewBindStructEx(testOrder{Lines: []testLine{{Name: "a"}, {Name: "b"}, {Name: "c"}}})
+	var paths []string
+	b.AddChangeListener(func(path string) { paths = append(paths, path) })
+
+	item, _ := b.GetItem("Lines")
+	lines := item.(binding.UntypedList)
+	values, _ := lines.Get()
+	second := values[1].(*BindStruct[any])
+	price, _ := second.GetItem("Price")
+	_ = price.(binding.Float).Set(9.5)
+
+	if b.Value().Lines[1].Price != 9.5 {
+		t.Fatalf("nested edit not written back: %+v", b.Value().Lines)
+	}
+	if len(paths) == 0 || paths[len(paths)-1] != "Lines[1].Price" {
+		t.Fatalf("unexpected changed paths: %v", paths)
+	}
+
+	b.SetValue(testOrder{Lines: []testLine{{Name: "x"}}})
+	b.Flush()
+	values, _ = lines.Get()
+	if len(values) != 1 || len(b.Value().Lines) != 1 || b.Value().Lines[0].Name != "x" {
+		t.Fatalf("list not truncated: %+v", b.Value().Lines)
+	}
+
+	b.mu.RLock()
+	_, stale := b.watched["Lines"][second]
+	watched := len(b.watched["Lines"])
+	b.mu.RUnlock()
+	if stale || watched != 2 {
+		t.Fatalf("removed items should no longer be watched: %d", watched)
+	}
+
+	_ = price.(binding.Float).Set(1)
+	if len(b.Value().Lines) != 1 || b.Value().Lines[0].Price != 0 {
+		t.Fatalf("removed nested struct should not write back: %+v", b.Value().Lines)
+	}
+}
@@ -0,0 +1,179 @@
+package mybinding
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// FieldChange 相对基准值发生变化的字段
+type FieldChange struct {
+	Path string // 例如 "Name"、"Address.City"、"Items[1].Price"
+	Old  any
+	New  any
+}
+
+// IsDirty 当前值与基准值（创建时的值或最近一次 Commit 的值）是否不同
+func (b *BindStruct[T]) IsDirty() bool {
+	return len(b.Changes()) > 0
+}
+
+// Dirty IsDirty 的绑定，可用于控制保存按钮的启用状态
+func (b *BindStruct[T]) Dirty() binding.Bool {
+	return b.dirty
+}
+
+// Changes 列出相对基准值发生变化的字段；长度不同的切片、映射作为整体比较
+func (b *BindStruct[T]) Changes() []FieldChange {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var changes []FieldChange
+	diffValue("", reflect.ValueOf(&b.original).Elem(), reflect.ValueOf(&b.value).Elem(), &changes)
+	return changes
+}
+
+// Original 基准值的副本
+func (b *BindStruct[T]) Original() T {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return cloneValue(b.original)
+}
+
+// Reset 恢复为基准值
+func (b *BindStruct[T]) Reset() {
+	b.SetValue(b.Original())
+}
+
+// Commit 以当前值作为新的基准值，例如保存成功后调用；SetValue 不会改变基准值，加载新数据后也需要调用
+func (b *BindStruct[T]) Commit() {
+	b.mu.Lock()
+	b.original = cloneValue(b.value)
+	b.mu.Unlock()
+
+	b.updateDirty()
+}
+
+func (b *BindStruct[T]) updateDirty() {
+	b.dirty.Set(b.IsDirty())
+}
+
+// diffValue 递归比较 old 与 cur，将不同的叶子字段追加到 changes
+func diffValue(path string, old, cur reflect.Value, changes *[]FieldChange) {
+	if old.Kind() == reflect.Interface {
+		old = old.Elem()
+	}
+	if cur.Kind() == reflect.Interface {
+		cur = cur.Elem()
+	}
+	if !old.IsValid() || !cur.IsValid() || old.Type() != cur.Type() {
+		if old.IsValid() != cur.IsValid() || (old.IsValid() && !reflect.DeepEqual(old.Interface(), cur.Interface())) {
+			*changes = append(*changes, FieldChange{Path: path, Old: valueOf(old), New: valueOf(cur)})
+		}
+		return
+	}
+
+	switch {
+	case old.Type() == timeType:
+		if !old.Interface().(time.Time).Equal(cur.Interface().(time.Time)) {
+			*changes = append(*changes, FieldChange{Path: path, Old: old.Interface(), New: cur.Interface()})
+		}
+	case old.Kind() == reflect.Ptr && old.Type().Elem().Kind() == reflect.Struct:
+		// nil 指针与零值结构体视为相同，与绑定时的处理一致
+		diffValue(path, structValue(old), structValue(cur), changes)
+	case old.Kind() == reflect.Struct:
+		t := old.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if !t.Field(i).IsExported() {
+				continue
+			}
+			diffValue(joinPath(path, t.Field(i).Name), old.Field(i), cur.Field(i), changes)
+		}
+	case old.Kind() == reflect.Slice && old.Len() == cur.Len() && isStructElem(old.Type().Elem()):
+		for i := 0; i < old.Len(); i++ {
+			diffValue(fmt.Sprintf("%s[%d]", path, i), old.Index(i), cur.Index(i), changes)
+		}
+	case old.Kind() == reflect.Slice || old.Kind() == reflect.Map:
+		if old.Len() == 0 && cur.Len() == 0 {
+			return
+		}
+		if !reflect.DeepEqual(old.Interface(), cur.Interface()) {
+			*changes = append(*changes, FieldChange{Path: path, Old: old.Interface(), New: cur.Interface()})
+		}
+	default:
+		if !reflect.DeepEqual(old.Interface(), cur.Interface()) {
+			*changes = append(*changes, FieldChange{Path: path, Old: old.Interface(), New: cur.Interface()})
+		}
+	}
+}
+
+// cloneValue 深拷贝 v
+func cloneValue[T any](v T) T {
+	var cp T
+	reflect.ValueOf(&cp).Elem().Set(deepCopy(reflect.ValueOf(&v).Elem()))
+	return cp
+}
+
+// deepCopy 复制值，指针、切片、映射都会分配新的内存，避免与基准值共享
+func deepCopy(v reflect.Value) reflect.Value {
+	cp := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Interface:
+		if !v.IsNil() {
+			cp.Set(deepCopy(v.Elem()))
+		}
+	case reflect.Ptr:
+		if !v.IsNil() {
+			p := reflect.New(v.Type().Elem())
+			p.Elem().Set(deepCopy(v.Elem()))
+			cp.Set(p)
+		}
+	case reflect.Struct:
+		cp.Set(v)
+		if v.Type() == timeType {
+			break
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if cp.Field(i).CanSet() {
+				cp.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+	case reflect.Slice:
+		if !v.IsNil() {
+			s := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+			for i := 0; i < v.Len(); i++ {
+				s.Index(i).Set(deepCopy(v.Index(i)))
+			}
+			cp.Set(s)
+		}
+	case reflect.Map:
+		if !v.IsNil() {
+			m := reflect.MakeMapWithSize(v.Type(), v.Len())
+			iter := v.MapRange()
+			for iter.Next() {
+				m.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+			}
+			cp.Set(m)
+		}
+	default:
+		cp.Set(v)
+	}
+	return cp
+}
+
+func valueOf(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
@@ -0,0 +1,65 @@
+package mybinding
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestBindStruct_Dirty(t *testing.T) {
+	test.NewApp()
+
+	b := NewBindStructEx(testOrder{Tags: []string{"a"}, Lines: []testLine{{Name: "a", Price: 1}, {Name: "b", Price: 2}}})
+	if b.IsDirty() || len(b.Changes()) != 0 {
+		t.Fatal("new BindStruct should not be dirty")
+	}
+
+	item, _ := b.GetItem("Lines")
+	values, _ := item.(binding.UntypedList).Get()
+	price, _ := values[1].(*BindStruct[any]).GetItem("Price")
+	_ = price.(binding.Float).Set(9.5)
+
+	if dirty, _ := b.Dirty().Get(); !dirty || !b.IsDirty() {
+		t.Fatal("BindStruct should be dirty after an edit")
+	}
+	changes := b.Changes()
+	if len(changes) != 1 || changes[0].Path != "Lines[1].Price" || changes[0].Old != 2.0 || changes[0].New != 9.5 {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+
+	tags, _ := b.GetItem("Tags")
+	_ = tags.(binding.StringList).SetValue(0, "z")
+	b.Flush()
+	changes = b.Changes()
+	if len(changes) != 2 || changes[0].Path != "Tags" {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+	if old, cur := changes[0].Old.([]string), changes[0].New.([]string); old[0] != "a" || cur[0] != "z" {
+		t.Fatalf("unexpected tag change: %+v", changes[0])
+	}
+
+	b.Reset()
+	b.Flush()
+	if dirty, _ := b.Dirty().Get(); dirty || b.IsDirty() {
+		t.Fatalf("Reset should clear dirty: %+v", b.Changes())
+	}
+	if v := b.Value(); v.Tags[0] != "a" || v.Lines[1].Price != 2 {
+		t.Fatalf("Reset should restore the original: %+v", v)
+	}
+	if p, _ := price.(binding.Float).Get(); p != 2 {
+		t.Fatalf("Reset should update bindings: %v", p)
+	}
+
+	_ = price.(binding.Float).Set(5)
+	b.Commit()
+	if dirty, _ := b.Dirty().Get(); dirty || b.IsDirty() || b.Original().Lines[1].Price != 5 {
+		t.Fatal("Commit should set a new baseline")
+	}
+
+	_ = price.(binding.Float).Set(6)
+	changes = b.Changes()
+	if len(changes) != 1 || changes[0].Old != 5.0 || changes[0].New != 6.0 {
+		t.Fatalf("changes should be relative to the committed baseline: %+v", changes)
+	}
+}
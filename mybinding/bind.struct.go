@@ -3,17 +3,25 @@ package mybinding
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
+	"time"
 
 	"fyne.io/fyne/v2/data/binding"
 )
 
 // BindStructEx 泛型版，持有原始值
 type BindStruct[T any] struct {
-	value     T
-	items     map[string]binding.DataItem
-	computeds map[string]func(T) any
-	mu        sync.RWMutex
+	value      T
+	original   T            // 基准值，用于判断是否修改过，见 Commit
+	dirty      binding.Bool // 与 IsDirty 同步
+	items      map[string]binding.DataItem
+	computeds  map[string]func(T) any
+	listeners  map[int]func(path string)
+	listenerID int
+	watched    map[string]map[any]func() // 集合路径 -> 已监听的元素（列表/映射的子项、嵌套的 BindStruct）及取消监听的函数
+	syncing    bool                      // SetValue 正在更新绑定数据，期间忽略回写
+	mu         sync.RWMutex
 }
 
 // NewBindStructEx 初始化泛型版
@@ -22,11 +30,32 @@ func NewBindStructEx[T any](input T) *BindStruct[T] {
 		value:     input,
 		items:     make(map[string]binding.DataItem),
 		computeds: make(map[string]func(T) any),
+		listeners: make(map[int]func(path string)),
+		watched:   make(map[string]map[any]func()),
 	}
+	bs.original = cloneValue(input)
+	bs.dirty = binding.NewBool()
 	bs.extractStruct("", reflect.ValueOf(input))
+	bs.AddChangeListener(func(string) { bs.updateDirty() })
 	return bs
 }
 
+// AddChangeListener 注册结构体变化的监听：绑定的控件修改字段后回调对应的路径（例如 "Address.City"），
+// 调用 SetValue 时路径为空；切片/映射字段的修改异步回调（见 Flush）；返回的函数用于取消监听
+func (b *BindStruct[T]) AddChangeListener(fn func(path string)) (remove func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.listenerID++
+	id := b.listenerID
+	b.listeners[id] = fn
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.listeners, id)
+	}
+}
+
 // 新增方法：添加计算字段
 func (b *BindStruct[T]) AddComputedField(name string, compute func(T) any) {
 	b.mu.Lock()
@@ -84,8 +113,8 @@ func (b *BindStruct[T]) GetItem(path string) (binding.DataItem, error) {
 }
 
 func (b *BindStruct[T]) GetOrCreateItem(path string, createItem binding.DataItem) binding.DataItem {
-	b.mu.RLock()
-	defer b.mu.RUnlock()
+	b.mu.Lock()
+	defer b.mu.Unlock()
 	item, ok := b.items[path]
 	if !ok {
 		b.items[path] = createItem
@@ -105,37 +134,191 @@ func (b *BindStruct[T]) Value() T {
 // SetValue 设置新值并更新绑定数据
 func (b *BindStruct[T]) SetValue(newVal T) {
 	b.mu.Lock()
-	defer b.mu.Unlock()
-
 	b.value = newVal
-	b.updateStruct("", reflect.ValueOf(newVal))
+	var pending []func()
+	b.updateStruct("", reflect.ValueOf(newVal), &pending)
+	pending = append(pending, b.computedUpdates(newVal)...)
+	b.syncing = true
+	b.mu.Unlock()
+
+	// 绑定数据的监听可能在当前 goroutine 中同步执行（回写字段时需要加锁），因此在锁外更新；
+	// 更新过程中集合绑定处于中间状态，此时的回写会被忽略
+	for _, fn := range pending {
+		fn()
+	}
 
-	// 更新计算字段
+	b.mu.Lock()
+	b.syncing = false
+	b.mu.Unlock()
+	b.fireChanged("")
+}
+
+// computedUpdates 计算字段的更新，调用方需持有锁，返回的函数在锁外执行
+func (b *BindStruct[T]) computedUpdates(val T) []func() {
+	var pending []func()
 	for path, compute := range b.computeds {
-		result := compute(newVal)
+		result := compute(val)
 		if item, ok := b.items[path]; ok {
 			switch data := item.(type) {
 			case binding.Int:
 				v := reflect.ValueOf(result)
 				switch v.Kind() {
 				case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-					data.Set(int(reflect.ValueOf(result).Int()))
+					pending = append(pending, func() { data.Set(int(v.Int())) })
 					break
 				case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-					data.Set(int(reflect.ValueOf(result).Uint()))
+					pending = append(pending, func() { data.Set(int(v.Uint())) })
 					break
 				}
 
 				break
 			case binding.Float:
-				data.Set(reflect.ValueOf(result).Float())
+				pending = append(pending, func() { data.Set(reflect.ValueOf(result).Float()) })
 				break
 			case binding.String:
-				data.Set(fmt.Sprintf("%v", result))
+				pending = append(pending, func() { data.Set(fmt.Sprintf("%v", result)) })
 				break
 			}
 		}
 	}
+	return pending
+}
+
+// writeBack 绑定的数据被修改（例如编辑了 Entry）时写回结构体对应的字段
+func (b *BindStruct[T]) writeBack(path string, item binding.DataItem) {
+	if b.writeField(path, item) {
+		b.fireChanged(path)
+	}
+}
+
+// writeField 在值的副本上修改字段，有变化时替换当前值并更新计算字段；
+// 路径上的指针也会复制（见 fieldByPath），不会修改调用方传入的值以及之前 Value() 返回的值，同时支持 T 为接口类型
+func (b *BindStruct[T]) writeField(path string, item binding.DataItem) bool {
+	b.mu.Lock()
+	if b.syncing {
+		b.mu.Unlock()
+		return false
+	}
+
+	root := reflect.ValueOf(&b.value).Elem()
+	if root.Kind() == reflect.Interface {
+		root = root.Elem()
+	}
+	if !root.IsValid() {
+		b.mu.Unlock()
+		return false
+	}
+
+	cp := reflect.New(root.Type()).Elem()
+	cp.Set(root)
+	field, ok := fieldByPath(cp, path)
+	if !ok || !setField(field, item) {
+		b.mu.Unlock()
+		return false
+	}
+	b.value = cp.Interface().(T)
+	pending := b.computedUpdates(b.value)
+	b.mu.Unlock()
+
+	for _, fn := range pending {
+		fn()
+	}
+	return true
+}
+
+func (b *BindStruct[T]) fireChanged(path string) {
+	b.mu.RLock()
+	listeners := make([]func(path string), 0, len(b.listeners))
+	for _, fn := range b.listeners {
+		listeners = append(listeners, fn)
+	}
+	b.mu.RUnlock()
+
+	for _, fn := range listeners {
+		fn(path)
+	}
+}
+
+// bindItem 保存字段对应的 DataItem 并监听其变化，调用方需持有锁或在初始化阶段调用
+func (b *BindStruct[T]) bindItem(path string, item binding.DataItem) {
+	b.items[path] = item
+	item.AddListener(binding.NewDataListener(func() {
+		b.writeBack(path, item)
+	}))
+}
+
+// fieldByPath 按 "A.B.C" 路径查找可设置的字段；路径上的指针按写时复制处理：
+// 分配新的结构体并复制原指向的值（nil 指针为零值），避免修改与其它值共享的结构体
+func fieldByPath(v reflect.Value, path string) (reflect.Value, bool) {
+	for _, name := range strings.Split(path, ".") {
+		for v.Kind() == reflect.Ptr {
+			if !v.CanSet() || v.Type().Elem().Kind() != reflect.Struct {
+				return reflect.Value{}, false
+			}
+			p := reflect.New(v.Type().Elem())
+			if !v.IsNil() {
+				p.Elem().Set(v.Elem())
+			}
+			v.Set(p)
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+
+		v = v.FieldByName(name)
+		if !v.IsValid() || !v.CanSet() {
+			return reflect.Value{}, false
+		}
+	}
+	return v, true
+}
+
+// setField 将 DataItem 的值写入字段，值没有变化时返回 false
+func setField(field reflect.Value, item binding.DataItem) bool {
+	switch data := item.(type) {
+	case binding.Int:
+		val, err := data.Get()
+		if err != nil {
+			return false
+		}
+		switch field.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if field.Int() == int64(val) || field.OverflowInt(int64(val)) {
+				return false
+			}
+			field.SetInt(int64(val))
+			return true
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if val < 0 || field.Uint() == uint64(val) || field.OverflowUint(uint64(val)) {
+				return false
+			}
+			field.SetUint(uint64(val))
+			return true
+		}
+	case binding.Float:
+		val, err := data.Get()
+		if err != nil || field.Float() == val {
+			return false
+		}
+		field.SetFloat(val)
+		return true
+	case binding.String:
+		val, err := data.Get()
+		if err != nil || field.Kind() != reflect.String || field.String() == val {
+			return false
+		}
+		field.SetString(val)
+		return true
+	case binding.Bool:
+		val, err := data.Get()
+		if err != nil || field.Kind() != reflect.Bool || field.Bool() == val {
+			return false
+		}
+		field.SetBool(val)
+		return true
+	}
+	return setCollectionField(field, item)
 }
 
 // 递归绑定字段
@@ -149,39 +332,63 @@ func (b *BindStruct[T]) extractStruct(prefix string, v reflect.Value) {
 	t := v.Type()
 	for i := 0; i < v.NumField(); i++ {
 		field := t.Field(i)
+		// 未导出字段无法读取（Interface 会 panic）也无法写回，不绑定
+		if !field.IsExported() {
+			continue
+		}
 		fieldVal := v.Field(i)
 		path := field.Name
 		if prefix != "" {
 			path = prefix + "." + field.Name
 		}
+		if fieldVal.Type() == timeType {
+			it := NewTime()
+			it.Set(fieldVal.Interface().(time.Time))
+			b.bindItem(path, it)
+			continue
+		}
 		switch fieldVal.Kind() {
 		case reflect.Struct:
 			b.extractStruct(path, fieldVal)
 			break
+		case reflect.Ptr:
+			// nil 指针按零值绑定，控件修改后写回时再分配
+			if fieldVal.Type().Elem().Kind() == reflect.Struct {
+				if fieldVal.IsNil() {
+					fieldVal = reflect.New(fieldVal.Type().Elem())
+				}
+				b.extractStruct(path, fieldVal)
+			}
+			break
+		case reflect.Slice, reflect.Map:
+			if it := b.newCollectionItem(path, fieldVal); it != nil {
+				b.bindCollection(path, it)
+			}
+			break
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 			it := binding.NewInt()
 			it.Set(int(fieldVal.Int()))
-			b.items[path] = it
+			b.bindItem(path, it)
 			break
 		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 			it := binding.NewInt()
 			it.Set(int(fieldVal.Uint()))
-			b.items[path] = it
+			b.bindItem(path, it)
 			break
 		case reflect.Float32, reflect.Float64:
 			it := binding.NewFloat()
 			it.Set(fieldVal.Float())
-			b.items[path] = it
+			b.bindItem(path, it)
 			break
 		case reflect.String:
 			it := binding.NewString()
 			it.Set(fieldVal.String())
-			b.items[path] = it
+			b.bindItem(path, it)
 			break
 		case reflect.Bool:
 			it := binding.NewBool()
 			it.Set(fieldVal.Bool())
-			b.items[path] = it
+			b.bindItem(path, it)
 			break
 		default:
 			// 忽略
@@ -190,7 +397,7 @@ func (b *BindStruct[T]) extractStruct(prefix string, v reflect.Value) {
 }
 
 // 递归更新已有字段
-func (b *BindStruct[T]) updateStruct(prefix string, v reflect.Value) {
+func (b *BindStruct[T]) updateStruct(prefix string, v reflect.Value, pending *[]func()) {
 	for v.Kind() == reflect.Ptr {
 		v = v.Elem()
 	}
@@ -200,33 +407,56 @@ func (b *BindStruct[T]) updateStruct(prefix string, v reflect.Value) {
 	t := v.Type()
 	for i := 0; i < v.NumField(); i++ {
 		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
 		fieldVal := v.Field(i)
 		path := field.Name
 		if prefix != "" {
 			path = prefix + "." + field.Name
 		}
-		if fieldVal.Kind() == reflect.Struct {
-			b.updateStruct(path, fieldVal)
+		if fieldVal.Type() == timeType {
+			if data, ok := b.items[path].(Time); ok {
+				val := fieldVal.Interface().(time.Time)
+				*pending = append(*pending, func() { data.Set(val) })
+			}
+		} else if fieldVal.Kind() == reflect.Struct {
+			b.updateStruct(path, fieldVal, pending)
+		} else if fieldVal.Kind() == reflect.Ptr && fieldVal.Type().Elem().Kind() == reflect.Struct {
+			if fieldVal.IsNil() {
+				fieldVal = reflect.New(fieldVal.Type().Elem())
+			}
+			b.updateStruct(path, fieldVal, pending)
 		} else if item, ok := b.items[path]; ok {
+			if fieldVal.Kind() == reflect.Slice || fieldVal.Kind() == reflect.Map {
+				*pending = append(*pending, b.collectionUpdate(path, item, fieldVal))
+				continue
+			}
+
 			switch data := item.(type) {
 			case binding.Int:
 				switch fieldVal.Kind() {
 				case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-					data.Set(int(fieldVal.Int()))
+					val := int(fieldVal.Int())
+					*pending = append(*pending, func() { data.Set(val) })
 					break
 				case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-					data.Set(int(fieldVal.Uint()))
+					val := int(fieldVal.Uint())
+					*pending = append(*pending, func() { data.Set(val) })
 					break
 				}
 				break
 			case binding.Float:
-				data.Set(fieldVal.Float())
+				val := fieldVal.Float()
+				*pending = append(*pending, func() { data.Set(val) })
 				break
 			case binding.String:
-				data.Set(fieldVal.String())
+				val := fieldVal.String()
+				*pending = append(*pending, func() { data.Set(val) })
 				break
 			case binding.Bool:
-				data.Set(fieldVal.Bool())
+				val := fieldVal.Bool()
+				*pending = append(*pending, func() { data.Set(val) })
 				break
 			}
 		}
@@ -0,0 +1,226 @@
+package mybinding
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+const (
+	undoDefaultLimit    = 100
+	undoDefaultCoalesce = time.Second
+)
+
+// undoStep 一次可撤销的修改，before/after 为修改前后的整体快照
+type undoStep[T any] struct {
+	path   string
+	before T
+	after  T
+	time   time.Time
+}
+
+// UndoManager 基于 BindStruct 的撤销/重做：记录每次字段修改，
+// 同一字段在 coalesce 间隔内的连续修改（例如连续输入）合并为一步
+type UndoManager[T any] struct {
+	bs       *BindStruct[T]
+	undo     []undoStep[T]
+	redo     []undoStep[T]
+	current  T // 最近一次记录时的值
+	limit    int
+	coalesce time.Duration
+	sealed   bool // 为 true 时下一次修改不与上一步合并
+	applying bool // Undo/Redo 正在调用 SetValue，期间的变化不记录
+	canUndo  binding.Bool
+	canRedo  binding.Bool
+	remove   func()
+	mu       sync.Mutex
+}
+
+// NewUndoManager 创建撤销管理器，默认最多保留 100 步，1 秒内的连续输入合并为一步
+func NewUndoManager[T any](bs *BindStruct[T]) *UndoManager[T] {
+	m := &UndoManager[T]{
+		bs:       bs,
+		current:  cloneValue(bs.Value()),
+		limit:    undoDefaultLimit,
+		coalesce: undoDefaultCoalesce,
+		canUndo:  binding.NewBool(),
+		canRedo:  binding.NewBool(),
+	}
+	m.remove = bs.AddChangeListener(m.record)
+	return m
+}
+
+// SetLimit 设置最多保留的步数
+func (m *UndoManager[T]) SetLimit(limit int) {
+	if limit <= 0 {
+		return
+	}
+
+	m.mu.Lock()
+	m.limit = limit
+	if len(m.undo) > limit {
+		m.undo = m.undo[len(m.undo)-limit:]
+	}
+	m.mu.Unlock()
+}
+
+// SetCoalesce 设置合并间隔，为 0 时每次修改都是单独的一步
+func (m *UndoManager[T]) SetCoalesce(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.coalesce = d
+}
+
+// Seal 结束当前的合并，下一次修改记录为新的一步，例如输入框失去焦点时调用
+func (m *UndoManager[T]) Seal() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sealed = true
+}
+
+// CanUndo 是否可以撤销的绑定，可用于控制按钮的启用状态
+func (m *UndoManager[T]) CanUndo() binding.Bool {
+	return m.canUndo
+}
+
+// CanRedo 是否可以重做的绑定
+func (m *UndoManager[T]) CanRedo() binding.Bool {
+	return m.canRedo
+}
+
+// Undo 撤销一步，没有可撤销的修改时返回 false
+func (m *UndoManager[T]) Undo() bool {
+	m.mu.Lock()
+	if len(m.undo) == 0 {
+		m.mu.Unlock()
+		return false
+	}
+	step := m.undo[len(m.undo)-1]
+	m.undo = m.undo[:len(m.undo)-1]
+	m.redo = append(m.redo, step)
+	m.mu.Unlock()
+
+	m.apply(step.before)
+	return true
+}
+
+// Redo 重做一步，没有可重做的修改时返回 false
+func (m *UndoManager[T]) Redo() bool {
+	m.mu.Lock()
+	if len(m.redo) == 0 {
+		m.mu.Unlock()
+		return false
+	}
+	step := m.redo[len(m.redo)-1]
+	m.redo = m.redo[:len(m.redo)-1]
+	m.undo = append(m.undo, step)
+	m.mu.Unlock()
+
+	m.apply(step.after)
+	return true
+}
+
+// Clear 清空撤销/重做记录，例如保存或加载新数据后调用
+func (m *UndoManager[T]) Clear() {
+	m.mu.Lock()
+	m.undo, m.redo = nil, nil
+	m.current = cloneValue(m.bs.Value())
+	m.sealed = true
+	m.mu.Unlock()
+
+	m.updateState()
+}
+
+// Destroy 停止记录 BindStruct 的修改
+func (m *UndoManager[T]) Destroy() {
+	m.remove()
+}
+
+// BindShortcuts 在窗口上注册 Ctrl+Z 撤销、Ctrl+Shift+Z 与 Ctrl+Y 重做（macOS 上为 Cmd），返回的函数用于移除快捷键。
+// 驱动会把 Ctrl+Z、Ctrl+Y 转换为 fyne.ShortcutUndo、fyne.ShortcutRedo，因此按这两种快捷键注册；
+// 焦点在输入框时由输入框自身处理这些快捷键
+func (m *UndoManager[T]) BindShortcuts(win fyne.Window) (remove func()) {
+	shortcuts := []struct {
+		shortcut fyne.Shortcut
+		handler  func()
+	}{
+		{&fyne.ShortcutUndo{}, func() { m.Undo() }},
+		{&fyne.ShortcutRedo{}, func() { m.Redo() }},
+		{&desktop.CustomShortcut{KeyName: fyne.KeyZ, Modifier: fyne.KeyModifierShortcutDefault | fyne.KeyModifierShift}, func() { m.Redo() }},
+	}
+
+	c := win.Canvas()
+	for _, s := range shortcuts {
+		handler := s.handler
+		c.AddShortcut(s.shortcut, func(fyne.Shortcut) { handler() })
+	}
+	return func() {
+		for _, s := range shortcuts {
+			c.RemoveShortcut(s.shortcut)
+		}
+	}
+}
+
+// apply 将 BindStruct 设为 v，期间触发的变化不记录
+func (m *UndoManager[T]) apply(v T) {
+	m.mu.Lock()
+	m.applying = true
+	m.mu.Unlock()
+
+	m.bs.SetValue(cloneValue(v))
+
+	m.mu.Lock()
+	m.applying = false
+	m.current = cloneValue(m.bs.Value())
+	m.sealed = true
+	m.mu.Unlock()
+
+	m.updateState()
+}
+
+// record BindStruct 的变化监听
+func (m *UndoManager[T]) record(path string) {
+	val := cloneValue(m.bs.Value())
+
+	m.mu.Lock()
+	if m.applying {
+		m.mu.Unlock()
+		return
+	}
+	if reflect.DeepEqual(m.current, val) {
+		m.mu.Unlock()
+		return
+	}
+
+	now := time.Now()
+	if n := len(m.undo); n > 0 && !m.sealed && path != "" && m.undo[n-1].path == path && now.Sub(m.undo[n-1].time) < m.coalesce {
+		m.undo[n-1].after = val
+		m.undo[n-1].time = now
+	} else {
+		m.undo = append(m.undo, undoStep[T]{path: path, before: m.current, after: val, time: now})
+		if len(m.undo) > m.limit {
+			m.undo = m.undo[len(m.undo)-m.limit:]
+		}
+	}
+	m.current = val
+	m.redo = nil
+	m.sealed = false
+	m.mu.Unlock()
+
+	m.updateState()
+}
+
+func (m *UndoManager[T]) updateState() {
+	m.mu.Lock()
+	canUndo, canRedo := len(m.undo) > 0, len(m.redo) > 0
+	m.mu.Unlock()
+
+	m.canUndo.Set(canUndo)
+	m.canRedo.Set(canRedo)
+}
@@ -0,0 +1,96 @@
+package mybinding
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestUndoManager(t *testing.T) {
+	test.NewApp()
+
+	b := NewBindStructEx(testUser{First: "a"})
+	m := NewUndoManager(b)
+	defer m.Destroy()
+
+	first, _ := b.GetItem("First")
+	age, _ := b.GetItem("Age")
+	_ = first.(binding.String).Set("ab")
+	_ = first.(binding.String).Set("abc") // 连续输入合并为一步
+	_ = age.(binding.Int).Set(3)
+
+	if !m.Undo() || b.Value().Age != 0 || b.Value().First != "abc" {
+		t.Fatalf("undo should revert the last step: %+v", b.Value())
+	}
+	if !m.Undo() || b.Value().First != "a" {
+		t.Fatalf("rapid typing should be coalesced into one step: %+v", b.Value())
+	}
+	if s, _ := first.(binding.String).Get(); s != "a" {
+		t.Fatalf("binding not updated by undo: %s", s)
+	}
+	if canUndo, _ := m.CanUndo().Get(); canUndo || m.Undo() {
+		t.Fatal("nothing left to undo")
+	}
+
+	m.Redo()
+	m.Redo()
+	if v := b.Value(); v.First != "abc" || v.Age != 3 {
+		t.Fatalf("redo should reapply both steps: %+v", v)
+	}
+
+	m.Undo()
+	_ = first.(binding.String).Set("z")
+	if canRedo, _ := m.CanRedo().Get(); canRedo {
+		t.Fatal("a new change should clear the redo stack")
+	}
+
+	m.SetCoalesce(0)
+	m.Clear()
+	_ = first.(binding.String).Set("z1")
+	time.Sleep(time.Millisecond)
+	_ = first.(binding.String).Set("z2")
+	if len(m.undo) != 2 {
+		t.Fatalf("changes should not be coalesced when disabled: %d", len(m.undo))
+	}
+}
+
+func TestUndoManager_Shortcuts(t *testing.T) {
+	app := test.NewApp()
+	win := app.NewWindow("editor")
+	defer win.Close()
+
+	b := NewBindStructEx(testUser{First: "a"})
+	m := NewUndoManager(b)
+	remove := m.BindShortcuts(win)
+
+	first, _ := b.GetItem("First")
+	_ = first.(binding.String).Set("b")
+
+	// 与驱动一致：Ctrl+Z / Cmd+Z 以 fyne.ShortcutUndo 的形式送到画布
+	c := win.Canvas().(fyne.Shortcutable)
+	c.TypedShortcut(&fyne.ShortcutUndo{})
+	if b.Value().First != "a" {
+		t.Fatal("Ctrl+Z should undo")
+	}
+
+	c.TypedShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyZ, Modifier: fyne.KeyModifierShortcutDefault | fyne.KeyModifierShift})
+	if b.Value().First != "b" {
+		t.Fatal("Ctrl+Shift+Z should redo")
+	}
+
+	c.TypedShortcut(&fyne.ShortcutUndo{})
+	c.TypedShortcut(&fyne.ShortcutRedo{})
+	if b.Value().First != "b" {
+		t.Fatal("Ctrl+Y should redo")
+	}
+
+	remove()
+	c.TypedShortcut(&fyne.ShortcutUndo{})
+	if b.Value().First != "b" {
+		t.Fatal("removed shortcuts should not undo")
+	}
+}
@@ -0,0 +1,160 @@
+package mybinding
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+)
+
+type testAddress struct {
+	City string
+	Zip  int
+}
+
+type testUser struct {
+	First   string
+	Last    string
+	Age     uint8
+	Active  bool
+	Addr    *testAddress
+	Home    testAddress
+	private int
+}
+
+func TestBindStruct_WriteBack(t *testing.T) {
+	test.NewApp()
+
+	b := NewBindStructEx(testUser{First: "San", Last: "Zhang", Addr: &testAddress{City: "x"}})
+	var paths []string
+	remove := b.AddChangeListener(func(path string) { paths = append(paths, path) })
+	defer remove()
+
+	first, _ := b.GetItem("First")
+	_ = first.(binding.String).Set("Si")
+	age, _ := b.GetItem("Age")
+	_ = age.(binding.Int).Set(300) // 超出 uint8 范围，忽略
+	_ = age.(binding.Int).Set(30)
+	zip, _ := b.GetItem("Home.Zip")
+	_ = zip.(binding.Int).Set(100)
+
+	v := b.Value()
+	if v.First != "Si" || v.Age != 30 || v.Home.Zip != 100 {
+		t.Fatalf("fields not written back: %+v", v)
+	}
+
+	want := []string{"First", "Age", "Home.Zip"}
+	if len(paths) != len(want) {
+		t.Fatalf("unexpected changed paths: %v", paths)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Fatalf("unexpected changed paths: %v", paths)
+		}
+	}
+
+	b.SetValue(testUser{First: "Wu"})
+	if paths[len(paths)-1] != "" {
+		t.Fatal("SetValue should report an empty path")
+	}
+	if s, _ := first.(binding.String).Get(); s != "Wu" {
+		t.Fatalf("binding not updated by SetValue: %s", s)
+	}
+}
+
+func TestBindStruct_PointerCopyOnWrite(t *testing.T) {
+	test.NewApp()
+
+	orig := testUser{Addr: &testAddress{City: "x"}}
+	b := NewBindStructEx(orig)
+	before := b.Value()
+
+	city, _ := b.GetItem("Addr.City")
+	_ = city.(binding.String).Set("y")
+
+	if orig.Addr.City != "x" || before.Addr.City != "x" {
+		t.Fatal("write-back should not modify values shared with the caller")
+	}
+	if b.Value().Addr.City != "y" {
+		t.Fatalf("pointer field not written back: %+v", b.Value().Addr)
+	}
+
+	nilAddr := NewBindStructEx(testUser{})
+	zip, _ := nilAddr.GetItem("Addr.Zip")
+	_ = zip.(binding.Int).Set(0)
+	if nilAddr.Value().Addr != nil {
+		t.Fatal("nil pointer should stay nil when nothing changed")
+	}
+	_ = zip.(binding.Int).Set(1)
+	if addr := nilAddr.Value().Addr; addr == nil || addr.Zip != 1 {
+		t.Fatal("nil pointer should be allocated when a field changes")
+	}
+}
+
+func TestBindStruct_ComputedField(t *testing.T) {
+	test.NewApp()
+
+	b := NewBindStructEx(testUser{First: "San", Last: "Zhang"})
+	b.AddComputedField("FullName", func(u testUser) any { return u.Last + " " + u.First })
+	b.AddComputedField("NameLen", func(u testUser) any { return len(u.First) })
+
+	full, _ := b.GetItem("FullName")
+	n, _ := b.GetItem("NameLen")
+	first, _ := b.GetItem("First")
+	_ = first.(binding.String).Set("Sisi")
+
+	if s, _ := full.(binding.String).Get(); s != "Zhang Sisi" {
+		t.Fatalf("computed field not recomputed on write-back: %s", s)
+	}
+	if l, _ := n.(binding.Int).Get(); l != 4 {
+		t.Fatalf("computed field not recomputed on write-back: %d", l)
+	}
+
+	b.SetValue(testUser{First: "Wu", Last: "Li"})
+	if s, _ := full.(binding.String).Get(); s != "Li Wu" {
+		t.Fatalf("computed field not recomputed on SetValue: %s", s)
+	}
+}
+
+type testPrivateLine struct {
+	Name  string
+	stamp time.Time
+}
+
+type testPrivate struct {
+	Name    string
+	Lines   []testPrivateLine
+	created time.Time
+	extra   map[string]int
+	lines   []testPrivateLine
+	addr    *testAddress
+}
+
+func TestBindStruct_UnexportedFields(t *testing.T) {
+	test.NewApp()
+
+	in := testPrivate{
+		Name:    "a",
+		Lines:   []testPrivateLine{{Name: "x", stamp: time.Now()}},
+		created: time.Now(),
+		extra:   map[string]int{"k": 1},
+		lines:   []testPrivateLine{{Name: "y"}},
+		addr:    &testAddress{City: "z"},
+	}
+	b := NewBindStructEx(in)
+	for _, path := range []string{"created", "extra", "lines", "addr.City"} {
+		if _, err := b.GetItem(path); err == nil {
+			t.Fatalf("unexported field %s should not be bound", path)
+		}
+	}
+
+	name, _ := b.GetItem("Name")
+	_ = name.(binding.String).Set("b")
+	in.Name = "c"
+	b.SetValue(in)
+	b.Flush()
+	if v := b.Value(); v.Name != "c" || v.extra["k"] != 1 || !v.created.Equal(in.created) {
+		t.Fatalf("unexpected value: %+v", v)
+	}
+}
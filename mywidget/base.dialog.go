@@ -3,6 +3,7 @@ package mywidget
 import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/dialog"
+	"github.com/any-call/myfyne"
 	"golang.org/x/image/colornames"
 	"image/color"
 )
@@ -59,6 +60,11 @@ func (self *BaseDialogContent) CloseDialog(param any) {
 	}
 }
 
+// IsShowing 对话框是否正在显示（而不是在队列中等待）
+func (self *BaseDialogContent) IsShowing() bool {
+	return self.dialog != nil && myfyne.CurrentDialog(self.window) == self.dialog
+}
+
 func (self *BaseDialogContent) GetCloseParam() any {
 	return self.closeParam
 }
@@ -9,8 +9,9 @@ import (
 )
 
 type BasePage struct {
-	title string
-	id    int
+	title      string
+	id         int
+	closeParam any
 }
 
 func (self *BasePage) ShowLoading(win fyne.Window, title string, col color.Color, radius float64) *dialog.CustomDialog {
@@ -61,3 +62,28 @@ func (self *BasePage) WinClosed() {
 	fmt.Println("enter basePage WinClosed")
 	return
 }
+
+// OnShow 默认空实现，具体页面按需覆盖
+func (self *BasePage) OnShow() {}
+
+func (self *BasePage) OnHide() {}
+
+func (self *BasePage) OnFocus() {}
+
+func (self *BasePage) OnBlur() {}
+
+// OnBeforeClose 默认允许关闭
+func (self *BasePage) OnBeforeClose() bool {
+	return true
+}
+
+func (self *BasePage) OnResize(size fyne.Size) {}
+
+// SetCloseParam 设置页面关闭时回传的结果（用于模态页面）
+func (self *BasePage) SetCloseParam(param any) {
+	self.closeParam = param
+}
+
+func (self *BasePage) GetCloseParam() any {
+	return self.closeParam
+}
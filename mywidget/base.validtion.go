@@ -1,6 +1,7 @@
 package mywidget
 
 const (
-	RegexpNumber string = `^\d+$`
-	RegexpFloat  string = `^-?(?:\d+|\d*\.\d+)$`
+	RegexpNumber  string = `^\d+$`
+	RegexpInteger string = `^-?\d+$`
+	RegexpFloat   string = `^-?(?:\d+|\d*\.\d+)$`
 )
@@ -2,10 +2,8 @@ package mywidget
 
 import (
 	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/canvas"
-	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+	"github.com/any-call/myfyne"
 	"time"
 )
 
@@ -48,28 +46,22 @@ func (c *CopyableContainer[T]) GetContent() T {
 
 // Tapped 处理点击复制
 func (c *CopyableContainer[T]) Tapped(*fyne.PointEvent) {
-	clipboard := fyne.CurrentApp().Driver().AllWindows()[0].Clipboard()
-	clipboard.SetContent(c.text)
+	if c.text == "" {
+		return
+	}
+
+	myfyne.CopyFrom(c, c.text, "")
 	c.showToast("已复制")
 }
 
 // showToast 显示 "已复制" 提示
 func (c *CopyableContainer[T]) showToast(msg string) {
-	win := fyne.CurrentApp().Driver().AllWindows()[0] // 自动获取当前窗口
+	win := myfyne.GetWindow(c)
 	if win == nil {
-		return
+		if wins := fyne.CurrentApp().Driver().AllWindows(); len(wins) > 0 {
+			win = wins[0]
+		}
 	}
 
-	toast := canvas.NewText(msg, theme.Color(theme.ColorNameForeground))
-	toast.TextSize = 14
-	toast.Alignment = fyne.TextAlignCenter
-
-	overlay := container.NewWithoutLayout(toast)
-	overlay.Move(fyne.NewPos((win.Canvas().Size().Width-toast.MinSize().Width)/2, 0)) // 顶部居中
-	win.Canvas().Overlays().Add(overlay)
-
-	go func() {
-		time.Sleep(1 * time.Second) // 显示 1 秒
-		win.Canvas().Overlays().Remove(overlay)
-	}()
+	myfyne.ShowToastWithOptions(win, msg, myfyne.ToastOptions{Level: myfyne.ToastSuccess, Duration: time.Second})
 }
@@ -6,10 +6,12 @@ import (
 	"fyne.io/fyne/v2/driver/mobile"
 	"fyne.io/fyne/v2/widget"
 	"strconv"
+	"strings"
 )
 
 type EntryNumber struct {
 	widget.Entry
+	AllowNegative bool // 允许在开头输入负号，用于有符号整数与浮点数
 }
 
 func NewEntryNumber() *EntryNumber {
@@ -33,6 +35,12 @@ func NewEntryByFloat(f float64) *EntryNumber {
 func (self *EntryNumber) TypedRune(r rune) {
 	if (r >= '0' && r <= '9') || r == '.' {
 		self.Entry.TypedRune(r)
+		return
+	}
+
+	// 负号只能输入在开头且只能有一个
+	if r == '-' && self.AllowNegative && self.CursorColumn == 0 && !strings.HasPrefix(self.Text, "-") {
+		self.Entry.TypedRune(r)
 	}
 }
 
@@ -44,7 +52,7 @@ func (self *EntryNumber) TypedShortcut(shortcut fyne.Shortcut) {
 	}
 
 	content := paste.Clipboard.Content()
-	if _, err := strconv.ParseFloat(content, 64); err == nil {
+	if _, err := strconv.ParseFloat(content, 64); err == nil && (self.AllowNegative || !strings.HasPrefix(content, "-")) {
 		self.Entry.TypedShortcut(shortcut)
 	}
 }
@@ -0,0 +1,365 @@
+package mywidget
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/any-call/myfyne"
+)
+
+// FormDialogOptions 表单对话框参数
+type FormDialogOptions struct {
+	Title      string
+	Size       fyne.Size // 宽高都大于 0 时按该尺寸显示
+	SubmitText string    // 默认 "确定"
+	CancelText string    // 默认 "取消"
+}
+
+// ShowFormDialog 根据结构体字段生成表单对话框，点击确定时以确认状态返回编辑后的副本，value 本身不会被修改。
+//
+// 支持的字段类型：string -> Entry，整数/浮点 -> EntryNumber，bool -> Check，time.Time -> DatePicker/DateTimePicker；
+// 未导出字段及其它类型会被忽略。通过 form 标签配置，多个配置以 ";" 分隔，例如：
+//
+//	Name  string    `form:"label=姓名;order=1;hint=真实姓名;required"`
+//	Age   int       `form:"label=年龄;regex=number"`
+//	Birth time.Time `form:"label=生日;type=datetime"`
+//	Inner string    `form:"-"`
+//
+// regex 可以是正则表达式，也可以是 number、integer、float（对应 RegexpNumber、RegexpInteger、RegexpFloat），
+// 未指定时有符号整数默认 integer，无符号整数默认 number；
+// type 可选 multiline、password（字符串）以及 datetime（time.Time）
+func ShowFormDialog[T any](win fyne.Window, value *T, opts FormDialogOptions, onClose func(result myfyne.DialogResult[T])) (dialog.Dialog, error) {
+	content, err := NewFormDialogContent(value, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Size.Width > 0 && opts.Size.Height > 0 {
+		return myfyne.ShowDialogBySize(win, opts.Size, content, func(param any) {
+			if onClose != nil {
+				onClose(content.Result())
+			}
+		}), nil
+	}
+	return myfyne.ShowDialog[T](win, content, onClose), nil
+}
+
+// FormDialogContent 由结构体生成的表单对话框内容
+type FormDialogContent[T any] struct {
+	TypedDialogContent[T]
+	value  T
+	fields []*formField
+	opts   FormDialogOptions
+}
+
+type formField struct {
+	index    int
+	order    int
+	label    string
+	hint     string
+	required bool
+	pattern  *regexp.Regexp
+	object   fyne.CanvasObject
+	read     func(field reflect.Value) error // 将控件的值写回字段
+}
+
+// NewFormDialogContent 创建表单内容，value 必须指向结构体
+func NewFormDialogContent[T any](value *T, opts FormDialogOptions) (*FormDialogContent[T], error) {
+	if value == nil {
+		return nil, errors.New("form value is nil")
+	}
+
+	rv := reflect.ValueOf(value).Elem()
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("form value must be a struct, got %s", rv.Kind())
+	}
+
+	c := &FormDialogContent[T]{value: *value, opts: opts}
+	c.SetTitle(opts.Title)
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		field, err := newFormField(i, sf, rv.Field(i))
+		if err != nil {
+			return nil, err
+		}
+		if field != nil {
+			c.fields = append(c.fields, field)
+		}
+	}
+
+	sort.SliceStable(c.fields, func(i, j int) bool {
+		return c.fields[i].order < c.fields[j].order
+	})
+	return c, nil
+}
+
+func (c *FormDialogContent[T]) Content() fyne.CanvasObject {
+	form := widget.NewForm()
+	for _, f := range c.fields {
+		form.AppendItem(&widget.FormItem{Text: f.label, Widget: f.object, HintText: f.hint})
+	}
+
+	errLabel := widget.NewLabel("")
+	errLabel.Importance = widget.DangerImportance
+	errLabel.Hide()
+
+	form.SubmitText = c.opts.SubmitText
+	if form.SubmitText == "" {
+		form.SubmitText = "确定"
+	}
+	form.CancelText = c.opts.CancelText
+	if form.CancelText == "" {
+		form.CancelText = "取消"
+	}
+
+	form.OnCancel = c.Cancel
+	form.OnSubmit = func() {
+		result, err := c.collect()
+		if err != nil {
+			errLabel.SetText(err.Error())
+			errLabel.Show()
+			return
+		}
+		c.Confirm(result)
+	}
+
+	return container.NewVBox(form, errLabel)
+}
+
+// collect 将表单的值写入 value 的副本
+func (c *FormDialogContent[T]) collect() (T, error) {
+	result := c.value
+	rv := reflect.ValueOf(&result).Elem()
+	for _, f := range c.fields {
+		if err := f.read(rv.Field(f.index)); err != nil {
+			return c.value, err
+		}
+	}
+	return result, nil
+}
+
+func newFormField(index int, sf reflect.StructField, fv reflect.Value) (*formField, error) {
+	tag := sf.Tag.Get("form")
+	if tag == "-" {
+		return nil, nil
+	}
+
+	opts := parseFormTag(tag)
+	f := &formField{
+		index: index,
+		order: index,
+		label: sf.Name,
+		hint:  opts["hint"],
+	}
+	if v, ok := opts["label"]; ok && v != "" {
+		f.label = v
+	}
+	if v, ok := opts["order"]; ok {
+		order, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: invalid order %q", sf.Name, v)
+		}
+		f.order = order
+	}
+	_, f.required = opts["required"]
+
+	pattern := opts["regex"]
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if pattern == "" {
+			pattern = "integer"
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if pattern == "" {
+			pattern = "number"
+		}
+	case reflect.Float32, reflect.Float64:
+		if pattern == "" {
+			pattern = "float"
+		}
+	}
+	switch pattern {
+	case "":
+	case "number":
+		f.pattern = regexp.MustCompile(RegexpNumber)
+	case "integer":
+		f.pattern = regexp.MustCompile(RegexpInteger)
+	case "float":
+		f.pattern = regexp.MustCompile(RegexpFloat)
+	default:
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+		f.pattern = re
+	}
+
+	if fv.Type() == reflect.TypeOf(time.Time{}) {
+		f.bindTime(fv.Interface().(time.Time), opts["type"] == "datetime")
+		return f, nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		var entry *widget.Entry
+		switch opts["type"] {
+		case "multiline":
+			entry = widget.NewMultiLineEntry()
+		case "password":
+			entry = widget.NewPasswordEntry()
+		default:
+			entry = widget.NewEntry()
+		}
+		entry.SetText(fv.String())
+		entry.Validator = f.validator()
+		f.object = entry
+		f.read = func(field reflect.Value) error {
+			if err := entry.Validate(); err != nil {
+				return err
+			}
+			field.SetString(entry.Text)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		entry := NewEntryNumber()
+		entry.AllowNegative = true
+		entry.SetText(strconv.FormatInt(fv.Int(), 10))
+		entry.Validator = f.validator()
+		f.object = entry
+		f.read = func(field reflect.Value) error {
+			if err := entry.Validate(); err != nil {
+				return err
+			}
+			n, err := strconv.ParseInt(orZero(entry.Text), 10, field.Type().Bits())
+			if err != nil {
+				return fmt.Errorf("%s格式不正确", f.label)
+			}
+			field.SetInt(n)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		entry := NewEntryNumber()
+		entry.SetText(strconv.FormatUint(fv.Uint(), 10))
+		entry.Validator = f.validator()
+		f.object = entry
+		f.read = func(field reflect.Value) error {
+			if err := entry.Validate(); err != nil {
+				return err
+			}
+			n, err := strconv.ParseUint(orZero(entry.Text), 10, field.Type().Bits())
+			if err != nil {
+				return fmt.Errorf("%s格式不正确", f.label)
+			}
+			field.SetUint(n)
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		entry := NewEntryNumber()
+		entry.AllowNegative = true
+		entry.SetText(strconv.FormatFloat(fv.Float(), 'f', -1, fv.Type().Bits()))
+		entry.Validator = f.validator()
+		f.object = entry
+		f.read = func(field reflect.Value) error {
+			if err := entry.Validate(); err != nil {
+				return err
+			}
+			n, err := strconv.ParseFloat(orZero(entry.Text), field.Type().Bits())
+			if err != nil {
+				return fmt.Errorf("%s格式不正确", f.label)
+			}
+			field.SetFloat(n)
+			return nil
+		}
+	case reflect.Bool:
+		check := widget.NewCheck("", nil)
+		check.SetChecked(fv.Bool())
+		f.object = check
+		f.read = func(field reflect.Value) error {
+			field.SetBool(check.Checked)
+			return nil
+		}
+	default:
+		return nil, nil
+	}
+	return f, nil
+}
+
+func (f *formField) bindTime(t time.Time, withTime bool) {
+	var get func() time.Time
+	if withTime {
+		picker := NewDateTimePicker(t, f.hint, nil)
+		get = picker.GetTime
+		f.object = picker
+	} else {
+		picker := NewDatePicker(t, f.hint, nil)
+		get = picker.GetDate
+		f.object = picker
+	}
+
+	f.read = func(field reflect.Value) error {
+		v := get()
+		if f.required && v.IsZero() {
+			return fmt.Errorf("%s不能为空", f.label)
+		}
+		field.Set(reflect.ValueOf(v))
+		return nil
+	}
+}
+
+// validator 必填与正则校验
+func (f *formField) validator() fyne.StringValidator {
+	return func(s string) error {
+		if s == "" {
+			if f.required {
+				return fmt.Errorf("%s不能为空", f.label)
+			}
+			return nil
+		}
+
+		if f.pattern != nil && !f.pattern.MatchString(s) {
+			return fmt.Errorf("%s格式不正确", f.label)
+		}
+		return nil
+	}
+}
+
+// parseFormTag 解析 form 标签，例如 "label=姓名;order=1;required"
+func parseFormTag(tag string) map[string]string {
+	opts := make(map[string]string)
+	for _, part := range strings.Split(tag, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if k, v, ok := strings.Cut(part, "="); ok {
+			opts[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		} else {
+			opts[part] = ""
+		}
+	}
+	return opts
+}
+
+func orZero(s string) string {
+	if s == "" {
+		return "0"
+	}
+	return s
+}
@@ -0,0 +1,47 @@
+package mywidget
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+)
+
+type testForm struct {
+	Count int
+	Rate  float64
+	Size  uint
+}
+
+func TestFormDialog_NegativeNumber(t *testing.T) {
+	test.NewApp()
+
+	value := testForm{}
+	content, err := NewFormDialogContent(&value, FormDialogOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries := make([]*EntryNumber, len(content.fields))
+	for i, f := range content.fields {
+		entries[i] = f.object.(*EntryNumber)
+		entries[i].SetText("")
+	}
+
+	test.Type(entries[0], "-5-")
+	test.Type(entries[1], "-1.5")
+	test.Type(entries[2], "-3")
+	if entries[0].Text != "-5" || entries[1].Text != "-1.5" {
+		t.Fatalf("signed fields should accept a leading minus: %q %q", entries[0].Text, entries[1].Text)
+	}
+	if entries[2].Text != "3" {
+		t.Fatalf("unsigned field should reject minus: %q", entries[2].Text)
+	}
+
+	result, err := content.collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Count != -5 || result.Rate != -1.5 || result.Size != 3 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+	"github.com/any-call/myfyne"
 )
 
 // LogConsole 高性能日志控件（RichText + 定时刷新 + 增量刷新）
@@ -88,9 +89,7 @@ func (c *LogConsole) CopyAll() {
 	text := strings.Join(c.buf, "\n")
 	c.mu.Unlock()
 
-	if clipboard := fyne.CurrentApp().Driver().AllWindows()[0].Clipboard(); clipboard != nil {
-		clipboard.SetContent(text)
-	}
+	myfyne.CopyFrom(c, text, "日志")
 }
 
 // SetAutoScroll 设置自动滚动
@@ -0,0 +1,192 @@
+package mywidget
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/any-call/myfyne"
+)
+
+type navEntry struct {
+	page    myfyne.Page
+	content fyne.CanvasObject // 缓存页面内容，返回时保留页面状态
+}
+
+// Navigator 在同一个窗口内以栈的方式承载多个 Page，顶栏带返回按钮
+// Navigator 本身也实现了 myfyne.Page，可直接交给 myfyne.ShowPage 显示
+type Navigator struct {
+	widget.BaseWidget
+	stack      []navEntry
+	scaffold   *Scaffold
+	backBtn    *widget.Button
+	titleLabel *widget.Label
+	trailing   *fyne.Container
+	onChanged  func(top myfyne.Page)
+
+	// 窗口标识取自创建时的根页面，Replace 根页面后保持不变，否则窗口管理器会找不到窗口
+	winID    int
+	winTitle string
+	winSize  fyne.Size
+}
+
+// NewNavigator 创建导航器，root 为栈底页面，不能为 nil
+func NewNavigator(root myfyne.Page) *Navigator {
+	n := &Navigator{winID: root.WinID(), winTitle: root.WinTitle(), winSize: root.WinSize()}
+	n.backBtn = widget.NewButtonWithIcon("", theme.NavigateBackIcon(), func() {
+		n.Pop()
+	})
+	n.backBtn.Importance = widget.LowImportance
+	n.titleLabel = widget.NewLabel("")
+	n.titleLabel.TextStyle = fyne.TextStyle{Bold: true}
+	n.trailing = container.NewHBox()
+
+	topBar := container.NewVBox(
+		container.NewBorder(nil, nil, n.backBtn, n.trailing, n.titleLabel),
+		widget.NewSeparator(),
+	)
+	n.scaffold = NewScaffold(topBar, nil, nil, nil, nil)
+	n.ExtendBaseWidget(n)
+
+	n.stack = append(n.stack, navEntry{page: root, content: root.Content()})
+	n.show()
+	return n
+}
+
+func (n *Navigator) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(n.scaffold)
+}
+
+// Push 将页面压入栈顶并显示
+func (n *Navigator) Push(page myfyne.Page) {
+	if page == nil {
+		return
+	}
+
+	n.disappear(n.Top())
+	n.stack = append(n.stack, navEntry{page: page, content: page.Content()})
+	n.show()
+}
+
+// Pop 弹出栈顶页面并返回，栈中只剩根页面时不做处理
+func (n *Navigator) Pop() myfyne.Page {
+	if len(n.stack) <= 1 {
+		return nil
+	}
+
+	top := n.stack[len(n.stack)-1].page
+	n.stack = n.stack[:len(n.stack)-1]
+	n.disappear(top)
+	top.WinClosed()
+	n.show()
+	return top
+}
+
+// Replace 用新页面替换栈顶页面，替换根页面时 Navigator 的 WinID/WinTitle/WinSize 不变
+func (n *Navigator) Replace(page myfyne.Page) {
+	if page == nil {
+		return
+	}
+
+	top := n.stack[len(n.stack)-1].page
+	n.stack[len(n.stack)-1] = navEntry{page: page, content: page.Content()}
+	n.disappear(top)
+	top.WinClosed()
+	n.show()
+}
+
+// PopToRoot 弹出除根页面外的所有页面
+func (n *Navigator) PopToRoot() {
+	if len(n.stack) <= 1 {
+		return
+	}
+
+	n.disappear(n.Top())
+	for i := len(n.stack) - 1; i > 0; i-- {
+		n.stack[i].page.WinClosed()
+	}
+	n.stack = n.stack[:1]
+	n.show()
+}
+
+// Top 返回当前显示的页面
+func (n *Navigator) Top() myfyne.Page {
+	return n.stack[len(n.stack)-1].page
+}
+
+// Depth 返回栈中页面数量
+func (n *Navigator) Depth() int {
+	return len(n.stack)
+}
+
+// CanPop 是否可以返回上一页
+func (n *Navigator) CanPop() bool {
+	return len(n.stack) > 1
+}
+
+// SetOnChanged 设置栈顶页面变化时的回调
+func (n *Navigator) SetOnChanged(fn func(top myfyne.Page)) {
+	n.onChanged = fn
+}
+
+// SetTrailing 设置顶栏右侧的附加控件
+func (n *Navigator) SetTrailing(objs ...fyne.CanvasObject) {
+	n.trailing.Objects = objs
+	n.trailing.Refresh()
+}
+
+// GetScaffold 获取内部的 Scaffold，可用于设置底栏、侧边栏等
+func (n *Navigator) GetScaffold() *Scaffold {
+	return n.scaffold
+}
+
+// Content 实现 myfyne.Page
+func (n *Navigator) Content() fyne.CanvasObject {
+	return n
+}
+
+func (n *Navigator) WinTitle() string {
+	return n.winTitle
+}
+
+func (n *Navigator) WinID() int {
+	return n.winID
+}
+
+func (n *Navigator) WinSize() fyne.Size {
+	return n.winSize
+}
+
+// WinClosed 窗口关闭时依次释放栈中所有页面
+func (n *Navigator) WinClosed() {
+	n.disappear(n.Top())
+	for i := len(n.stack) - 1; i >= 0; i-- {
+		n.stack[i].page.WinClosed()
+	}
+}
+
+// show 显示栈顶页面并刷新顶栏
+func (n *Navigator) show() {
+	top := n.stack[len(n.stack)-1]
+	n.titleLabel.SetText(top.page.WinTitle())
+	if n.CanPop() {
+		n.backBtn.Show()
+	} else {
+		n.backBtn.Hide()
+	}
+	n.scaffold.SetContent(top.content)
+
+	if p, ok := top.page.(myfyne.PageAppear); ok {
+		p.OnAppear()
+	}
+
+	if n.onChanged != nil {
+		n.onChanged(top.page)
+	}
+}
+
+func (n *Navigator) disappear(page myfyne.Page) {
+	if p, ok := page.(myfyne.PageDisappear); ok {
+		p.OnDisappear()
+	}
+}
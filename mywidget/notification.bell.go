@@ -0,0 +1,210 @@
+package mywidget
+
+import (
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/any-call/myfyne"
+)
+
+var bellIconRes = theme.NewThemedResource(fyne.NewStaticResource("bell.svg", []byte(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24"><path d="M12 22c1.1 0 2-.9 2-2h-4c0 1.1.9 2 2 2zm6-6v-5c0-3.07-1.63-5.64-4.5-6.32V4c0-.83-.67-1.5-1.5-1.5s-1.5.67-1.5 1.5v.68C7.64 5.36 6 7.92 6 11v5l-2 2v1h16v-1l-2-2z"/></svg>`)))
+
+// NotificationBell 通知中心入口，显示未读数量角标，点击弹出通知历史面板；
+// 可放在 Scaffold 的顶部栏中，通知通过 myfyne.Notify 记录
+type NotificationBell struct {
+	widget.BaseWidget
+	panelSize fyne.Size
+	panel     *notificationPanel
+	popup     *widget.PopUp
+}
+
+// NewNotificationBell 创建通知铃铛
+func NewNotificationBell() *NotificationBell {
+	b := &NotificationBell{panelSize: fyne.NewSize(320, 400)}
+	b.ExtendBaseWidget(b)
+	return b
+}
+
+// SetPanelSize 设置通知面板尺寸，默认 320x400
+func (b *NotificationBell) SetPanelSize(size fyne.Size) *NotificationBell {
+	b.panelSize = size
+	return b
+}
+
+func (b *NotificationBell) Tapped(_ *fyne.PointEvent) {
+	b.ShowPanel()
+}
+
+// ShowPanel 在铃铛下方弹出通知历史面板
+func (b *NotificationBell) ShowPanel() {
+	c := fyne.CurrentApp().Driver().CanvasForObject(b)
+	if c == nil {
+		return
+	}
+
+	if b.panel == nil {
+		b.panel = newNotificationPanel()
+	}
+	b.panel.refresh()
+	b.popup = widget.NewPopUp(container.NewGridWrap(b.panelSize, b.panel.content), c)
+
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(b)
+	x := pos.X + b.Size().Width - b.panelSize.Width
+	if x < 0 {
+		x = 0
+	}
+	b.popup.ShowAtPosition(fyne.NewPos(x, pos.Y+b.Size().Height))
+}
+
+// HidePanel 关闭通知面板
+func (b *NotificationBell) HidePanel() {
+	if b.popup != nil {
+		b.popup.Hide()
+		b.popup = nil
+	}
+}
+
+func (b *NotificationBell) CreateRenderer() fyne.WidgetRenderer {
+	r := &notificationBellRenderer{
+		icon:  widget.NewIcon(bellIconRes),
+		badge: canvas.NewCircle(theme.Color(theme.ColorNameError)),
+		count: canvas.NewText("", theme.Color(theme.ColorNameForegroundOnError)),
+	}
+	r.count.TextSize = theme.CaptionTextSize()
+	r.count.Alignment = fyne.TextAlignCenter
+	r.remove = myfyne.OnNotificationsChanged(func() {
+		r.Refresh()
+		if b.panel != nil && b.popup != nil && b.popup.Visible() {
+			b.panel.refresh()
+		}
+	})
+	r.Refresh()
+	return r
+}
+
+type notificationBellRenderer struct {
+	icon   *widget.Icon
+	badge  *canvas.Circle
+	count  *canvas.Text
+	remove func()
+}
+
+func (r *notificationBellRenderer) Layout(size fyne.Size) {
+	r.icon.Resize(size)
+
+	d := fyne.Max(r.count.MinSize().Height, r.count.MinSize().Width+theme.InnerPadding()/2)
+	r.badge.Resize(fyne.NewSquareSize(d))
+	r.badge.Move(fyne.NewPos(size.Width-d*3/4, -d/4))
+	r.count.Resize(fyne.NewSquareSize(d))
+	r.count.Move(r.badge.Position())
+}
+
+func (r *notificationBellRenderer) MinSize() fyne.Size {
+	return fyne.NewSquareSize(theme.IconInlineSize() + theme.InnerPadding())
+}
+
+func (r *notificationBellRenderer) Refresh() {
+	unread := myfyne.UnreadNotifications()
+	if unread > 0 {
+		r.count.Text = strconv.Itoa(min(unread, 99))
+		if unread > 99 {
+			r.count.Text += "+"
+		}
+		r.badge.Show()
+		r.count.Show()
+	} else {
+		r.badge.Hide()
+		r.count.Hide()
+	}
+
+	r.badge.FillColor = theme.Color(theme.ColorNameError)
+	r.count.Color = theme.Color(theme.ColorNameForegroundOnError)
+	r.badge.Refresh()
+	r.count.Refresh()
+	r.icon.Refresh()
+}
+
+func (r *notificationBellRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.icon, r.badge, r.count}
+}
+
+func (r *notificationBellRenderer) Destroy() {
+	r.remove()
+}
+
+// notificationPanel 通知历史面板：全部已读、清空，点击单条通知标记为已读
+type notificationPanel struct {
+	items   []myfyne.Notification
+	list    *widget.List
+	empty   *widget.Label
+	content fyne.CanvasObject
+}
+
+func newNotificationPanel() *notificationPanel {
+	p := &notificationPanel{items: myfyne.Notifications()}
+	p.list = widget.NewList(
+		func() int { return len(p.items) },
+		func() fyne.CanvasObject {
+			title := widget.NewLabel("")
+			title.TextStyle = fyne.TextStyle{Bold: true}
+			title.Truncation = fyne.TextTruncateEllipsis
+			at := widget.NewLabel("")
+			at.Importance = widget.LowImportance
+			content := widget.NewLabel("")
+			content.Truncation = fyne.TextTruncateEllipsis
+			return container.NewBorder(nil, nil, widget.NewIcon(nil), nil,
+				container.NewVBox(container.NewBorder(nil, nil, nil, at, title), content))
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			n := p.items[id]
+			row := obj.(*fyne.Container)
+			body := row.Objects[0].(*fyne.Container)
+			icon := row.Objects[1].(*widget.Icon)
+			head := body.Objects[0].(*fyne.Container)
+
+			icon.SetResource(theme.NewColoredResource(n.Level.Icon(), n.Level.ColorName()))
+			title := head.Objects[0].(*widget.Label)
+			title.SetText(n.Title)
+			if n.Read {
+				title.Importance = widget.LowImportance
+			} else {
+				title.Importance = widget.MediumImportance
+			}
+			title.Refresh()
+			head.Objects[1].(*widget.Label).SetText(n.Time.Format("01-02 15:04"))
+			body.Objects[1].(*widget.Label).SetText(n.Content)
+		},
+	)
+	p.list.OnSelected = func(id widget.ListItemID) {
+		myfyne.MarkNotificationRead(p.items[id].ID)
+		p.list.UnselectAll()
+	}
+
+	p.empty = widget.NewLabelWithStyle("暂无通知", fyne.TextAlignCenter, fyne.TextStyle{})
+	readAll := widget.NewButton("全部已读", myfyne.MarkAllNotificationsRead)
+	readAll.Importance = widget.LowImportance
+	clear := widget.NewButton("清空", myfyne.ClearNotifications)
+	clear.Importance = widget.LowImportance
+
+	header := container.NewHBox(widget.NewLabelWithStyle("通知", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		layout.NewSpacer(), readAll, clear)
+	p.content = container.NewBorder(container.NewVBox(header, widget.NewSeparator()), nil, nil, nil,
+		container.NewStack(p.list, p.empty))
+
+	return p
+}
+
+func (p *notificationPanel) refresh() {
+	p.items = myfyne.Notifications()
+	if len(p.items) == 0 {
+		p.empty.Show()
+	} else {
+		p.empty.Hide()
+	}
+	p.list.Refresh()
+}
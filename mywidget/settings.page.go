@@ -0,0 +1,115 @@
+package mywidget
+
+import (
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	"github.com/any-call/myfyne"
+)
+
+// SettingsPage 根据 myfyne.RegisterSetting 注册的设置自动生成的设置页面，按分组显示；
+// 控件直接绑定设置的数据，修改即时生效并保存
+type SettingsPage struct {
+	BasePage
+	size fyne.Size
+}
+
+// NewSettingsPage 创建设置页面，size 为窗口尺寸
+func NewSettingsPage(winID int, title string, size fyne.Size) *SettingsPage {
+	p := &SettingsPage{size: size}
+	p.SetWinID(winID)
+	p.SetTitle(title)
+	return p
+}
+
+func (p *SettingsPage) WinSize() fyne.Size {
+	return p.size
+}
+
+func (p *SettingsPage) Content() fyne.CanvasObject {
+	reset := widget.NewButton("恢复默认", func() {
+		for _, e := range myfyne.RegisteredSettings() {
+			e.Reset()
+		}
+	})
+
+	return container.NewBorder(nil, container.NewHBox(layout.NewSpacer(), reset), nil, nil,
+		container.NewVScroll(NewSettingsForm()))
+}
+
+// NewSettingsForm 生成全部已注册设置的表单，可嵌入到其它页面中
+func NewSettingsForm() fyne.CanvasObject {
+	box := container.NewVBox()
+	var form *widget.Form
+	section := ""
+	for _, e := range myfyne.RegisteredSettings() {
+		opts := e.Options()
+		if form == nil || opts.Section != section {
+			section = opts.Section
+			form = widget.NewForm()
+			box.Add(widget.NewCard(section, "", form))
+		}
+
+		obj := settingWidget(e)
+		if obj == nil {
+			continue
+		}
+		form.AppendItem(&widget.FormItem{Text: opts.Label, Widget: obj, HintText: opts.Hint})
+	}
+	return box
+}
+
+// settingWidget 按设置类型生成绑定的控件
+func settingWidget(e myfyne.SettingEntry) fyne.CanvasObject {
+	opts := e.Options()
+	switch item := e.DataItem().(type) {
+	case binding.Bool:
+		return widget.NewCheckWithData("", item)
+	case binding.Int:
+		if opts.Max > opts.Min {
+			return settingSlider(binding.IntToFloat(item), opts, func(v float64) string {
+				return strconv.Itoa(int(v))
+			})
+		}
+		entry := NewEntryNumber()
+		entry.Bind(binding.IntToString(item))
+		return entry
+	case binding.Float:
+		if opts.Max > opts.Min {
+			return settingSlider(item, opts, func(v float64) string {
+				return strconv.FormatFloat(v, 'f', -1, 64)
+			})
+		}
+		entry := NewEntryNumber()
+		entry.Bind(binding.FloatToString(item))
+		return entry
+	case binding.String:
+		if len(opts.Choices) > 0 {
+			sel := widget.NewSelect(opts.Choices, nil)
+			sel.Bind(item)
+			return sel
+		}
+		return widget.NewEntryWithData(item)
+	}
+	return nil
+}
+
+func settingSlider(item binding.Float, opts myfyne.SettingOptions, format func(float64) string) fyne.CanvasObject {
+	slider := widget.NewSliderWithData(opts.Min, opts.Max, item)
+	if opts.Step > 0 {
+		slider.Step = opts.Step
+	}
+
+	value := widget.NewLabel("")
+	update := func() {
+		if v, err := item.Get(); err == nil {
+			value.SetText(format(v))
+		}
+	}
+	item.AddListener(binding.NewDataListener(update))
+	return container.NewBorder(nil, nil, nil, value, slider)
+}
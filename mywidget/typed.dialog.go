@@ -0,0 +1,29 @@
+package mywidget
+
+import (
+	"github.com/any-call/myfyne"
+)
+
+// TypedDialogContent 带类型结果的对话框内容基类，配合 myfyne.ShowDialog[T] 使用
+// 具体对话框嵌入它并实现 Content()，通过 Confirm/Cancel 关闭对话框
+type TypedDialogContent[T any] struct {
+	BaseDialogContent
+}
+
+// Confirm 以确认状态关闭对话框并返回 v
+func (self *TypedDialogContent[T]) Confirm(v T) {
+	self.CloseDialog(myfyne.DialogResult[T]{Value: v, Confirmed: true})
+}
+
+// Cancel 以取消状态关闭对话框
+func (self *TypedDialogContent[T]) Cancel() {
+	self.CloseDialog(myfyne.DialogResult[T]{})
+}
+
+// Result 获取关闭时的结果
+func (self *TypedDialogContent[T]) Result() myfyne.DialogResult[T] {
+	if result, ok := self.GetCloseParam().(myfyne.DialogResult[T]); ok {
+		return result
+	}
+	return myfyne.DialogResult[T]{}
+}
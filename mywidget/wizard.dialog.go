@@ -0,0 +1,186 @@
+package mywidget
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+// WizardStep 向导中的一步
+type WizardStep struct {
+	Title    string
+	Content  fyne.CanvasObject
+	Validate func() error // 点击下一步/完成前调用，返回错误时停留在当前步并显示错误
+	OnEnter  func()       // 进入该步时调用
+}
+
+// WizardDialog 多步骤向导对话框，配合 myfyne.ShowDialog[T] 使用，完成时以确认状态返回 result() 的结果
+type WizardDialog[T any] struct {
+	TypedDialogContent[T]
+	steps         []WizardStep
+	current       int
+	result        func() T
+	showIndicator bool
+
+	indicator *fyne.Container
+	body      *fyne.Container
+	errLabel  *widget.Label
+	backBtn   *widget.Button
+	nextBtn   *widget.Button
+}
+
+// NewWizardDialog 创建向导，steps 不能为空；result 在点击完成且最后一步校验通过后调用
+func NewWizardDialog[T any](title string, steps []WizardStep, result func() T) *WizardDialog[T] {
+	w := &WizardDialog[T]{
+		steps:         steps,
+		result:        result,
+		showIndicator: true,
+	}
+	w.SetTitle(title)
+	return w
+}
+
+// SetShowIndicator 设置是否显示顶部的步骤指示
+func (w *WizardDialog[T]) SetShowIndicator(show bool) *WizardDialog[T] {
+	w.showIndicator = show
+	if w.indicator != nil {
+		if show {
+			w.indicator.Show()
+		} else {
+			w.indicator.Hide()
+		}
+	}
+	return w
+}
+
+func (w *WizardDialog[T]) Content() fyne.CanvasObject {
+	w.indicator = container.NewHBox()
+	if !w.showIndicator {
+		w.indicator.Hide()
+	}
+
+	objs := make([]fyne.CanvasObject, 0, len(w.steps))
+	for _, step := range w.steps {
+		objs = append(objs, step.Content)
+	}
+	w.body = container.NewStack(objs...)
+
+	w.errLabel = widget.NewLabel("")
+	w.errLabel.Importance = widget.DangerImportance
+	w.errLabel.Wrapping = fyne.TextWrapWord
+	w.errLabel.Hide()
+
+	cancelBtn := widget.NewButton("取消", w.Cancel)
+	w.backBtn = widget.NewButton("上一步", w.Back)
+	w.nextBtn = widget.NewButton("下一步", w.Next)
+	w.nextBtn.Importance = widget.HighImportance
+
+	buttons := container.NewHBox(cancelBtn, layout.NewSpacer(), w.backBtn, w.nextBtn)
+	top := container.NewVBox(w.indicator, widget.NewSeparator())
+	bottom := container.NewVBox(w.errLabel, buttons)
+
+	w.current = 0
+	w.showStep()
+	return container.NewBorder(top, bottom, nil, nil, w.body)
+}
+
+// Current 当前步骤下标
+func (w *WizardDialog[T]) Current() int {
+	return w.current
+}
+
+// Next 校验当前步骤后前进，最后一步时完成向导
+func (w *WizardDialog[T]) Next() {
+	if !w.validate() {
+		return
+	}
+
+	if w.current == len(w.steps)-1 {
+		var v T
+		if w.result != nil {
+			v = w.result()
+		}
+		w.Confirm(v)
+		return
+	}
+
+	w.current++
+	w.showStep()
+}
+
+// Back 返回上一步，不做校验
+func (w *WizardDialog[T]) Back() {
+	if w.current == 0 {
+		return
+	}
+
+	w.current--
+	w.showStep()
+}
+
+func (w *WizardDialog[T]) validate() bool {
+	step := w.steps[w.current]
+	if step.Validate == nil {
+		return true
+	}
+
+	if err := step.Validate(); err != nil {
+		w.errLabel.SetText(err.Error())
+		w.errLabel.Show()
+		return false
+	}
+	return true
+}
+
+func (w *WizardDialog[T]) showStep() {
+	for i, obj := range w.body.Objects {
+		if i == w.current {
+			obj.Show()
+		} else {
+			obj.Hide()
+		}
+	}
+
+	w.errLabel.Hide()
+	if w.current == 0 {
+		w.backBtn.Disable()
+	} else {
+		w.backBtn.Enable()
+	}
+
+	if w.current == len(w.steps)-1 {
+		w.nextBtn.SetText("完成")
+	} else {
+		w.nextBtn.SetText("下一步")
+	}
+
+	w.refreshIndicator()
+	if step := w.steps[w.current]; step.OnEnter != nil {
+		step.OnEnter()
+	}
+}
+
+func (w *WizardDialog[T]) refreshIndicator() {
+	objs := make([]fyne.CanvasObject, 0, len(w.steps)*2)
+	for i, step := range w.steps {
+		if i > 0 {
+			objs = append(objs, widget.NewLabel("›"))
+		}
+
+		label := widget.NewLabel(fmt.Sprintf("%d. %s", i+1, step.Title))
+		switch {
+		case i == w.current:
+			label.Importance = widget.HighImportance
+			label.TextStyle = fyne.TextStyle{Bold: true}
+		case i > w.current:
+			label.Importance = widget.LowImportance
+		}
+		objs = append(objs, label)
+	}
+
+	w.indicator.Objects = objs
+	w.indicator.Refresh()
+}
@@ -0,0 +1,189 @@
+package myfyne
+
+import (
+	"sync"
+	"time"
+)
+
+const notificationDefaultLimit = 200
+
+// Notification 应用内通知，Level 与 toast 共用级别定义
+type Notification struct {
+	ID      int
+	Title   string
+	Content string
+	Level   ToastLevel
+	Time    time.Time
+	Read    bool
+}
+
+// notificationCenter 记录应用内通知历史，最新的在最前
+type notificationCenter struct {
+	items      []Notification
+	lastID     int
+	limit      int
+	mirror     bool
+	listeners  map[int]func()
+	listenerID int
+	mutex      sync.Mutex
+}
+
+var notifications = &notificationCenter{
+	limit:     notificationDefaultLimit,
+	listeners: make(map[int]func()),
+}
+
+// Notify 记录一条应用内通知；开启 SetNotificationMirror 时同时发送系统通知
+func Notify(title, content string, level ToastLevel) Notification {
+	nc := notifications
+	nc.mutex.Lock()
+	nc.lastID++
+	n := Notification{
+		ID:      nc.lastID,
+		Title:   title,
+		Content: content,
+		Level:   level,
+		Time:    time.Now(),
+	}
+	nc.items = append([]Notification{n}, nc.items...)
+	if len(nc.items) > nc.limit {
+		nc.items = nc.items[:nc.limit]
+	}
+	mirror := nc.mirror
+	nc.mutex.Unlock()
+
+	if mirror {
+		SendNotificationMsg(title, content)
+	}
+	nc.fireChanged()
+	return n
+}
+
+// SetNotificationMirror 设置是否将应用内通知同时发送为系统通知，默认不发送
+func SetNotificationMirror(enable bool) {
+	notifications.mutex.Lock()
+	defer notifications.mutex.Unlock()
+
+	notifications.mirror = enable
+}
+
+// SetNotificationLimit 设置保留的通知数量上限，超出时丢弃最早的通知，默认 200
+func SetNotificationLimit(limit int) {
+	if limit <= 0 {
+		return
+	}
+
+	nc := notifications
+	nc.mutex.Lock()
+	nc.limit = limit
+	changed := len(nc.items) > limit
+	if changed {
+		nc.items = nc.items[:limit]
+	}
+	nc.mutex.Unlock()
+
+	if changed {
+		nc.fireChanged()
+	}
+}
+
+// Notifications 返回通知历史的副本，最新的在最前
+func Notifications() []Notification {
+	notifications.mutex.Lock()
+	defer notifications.mutex.Unlock()
+
+	return append([]Notification(nil), notifications.items...)
+}
+
+// UnreadNotifications 未读通知数量
+func UnreadNotifications() int {
+	notifications.mutex.Lock()
+	defer notifications.mutex.Unlock()
+
+	count := 0
+	for _, n := range notifications.items {
+		if !n.Read {
+			count++
+		}
+	}
+	return count
+}
+
+// MarkNotificationRead 将指定通知标记为已读
+func MarkNotificationRead(id int) {
+	notifications.update(func(items []Notification) bool {
+		for i := range items {
+			if items[i].ID == id && !items[i].Read {
+				items[i].Read = true
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// MarkAllNotificationsRead 将全部通知标记为已读
+func MarkAllNotificationsRead() {
+	notifications.update(func(items []Notification) bool {
+		changed := false
+		for i := range items {
+			if !items[i].Read {
+				items[i].Read = true
+				changed = true
+			}
+		}
+		return changed
+	})
+}
+
+// ClearNotifications 清空通知历史
+func ClearNotifications() {
+	nc := notifications
+	nc.mutex.Lock()
+	changed := len(nc.items) > 0
+	nc.items = nil
+	nc.mutex.Unlock()
+
+	if changed {
+		nc.fireChanged()
+	}
+}
+
+// OnNotificationsChanged 注册通知变化（新增、已读、清空）的监听，回调在主线程执行；返回的函数用于取消监听
+func OnNotificationsChanged(fn func()) (remove func()) {
+	nc := notifications
+	nc.mutex.Lock()
+	nc.listenerID++
+	id := nc.listenerID
+	nc.listeners[id] = fn
+	nc.mutex.Unlock()
+
+	return func() {
+		nc.mutex.Lock()
+		delete(nc.listeners, id)
+		nc.mutex.Unlock()
+	}
+}
+
+func (nc *notificationCenter) update(fn func(items []Notification) bool) {
+	nc.mutex.Lock()
+	changed := fn(nc.items)
+	nc.mutex.Unlock()
+
+	if changed {
+		nc.fireChanged()
+	}
+}
+
+func (nc *notificationCenter) fireChanged() {
+	nc.mutex.Lock()
+	listeners := make([]func(), 0, len(nc.listeners))
+	for _, fn := range nc.listeners {
+		listeners = append(listeners, fn)
+	}
+	nc.mutex.Unlock()
+
+	for _, fn := range listeners {
+		RunOnMainAsync(fn)
+	}
+}
@@ -0,0 +1,98 @@
+package myfyne
+
+import (
+	"fyne.io/fyne/v2"
+)
+
+// 窗口尺寸记忆文件，保存在 app 的 Storage 目录下
+const winGeometryFile = "myfyne.window.geometry.json"
+
+// WinGeometry 记录窗口关闭时的状态
+// 注意：fyne 目前没有提供读取/设置窗口位置的接口，因此只记录尺寸与全屏状态
+type WinGeometry struct {
+	Width      float32 `json:"width"`
+	Height     float32 `json:"height"`
+	FullScreen bool    `json:"fullScreen"`
+}
+
+// rememberGeometry 固定尺寸的窗口以及实现 PageGeometry 并返回 false 的页面不做记忆；
+// 自动分配的 id（负数，见 allocWinID）随打开顺序变化，每次运行都不同，同样不做记忆
+func (wm *windowManager) rememberGeometry(page Page, fixedSize bool) bool {
+	if fixedSize || page.WinID() < 0 {
+		return false
+	}
+
+	if p, ok := page.(PageGeometry); ok {
+		return p.RememberGeometry()
+	}
+	return true
+}
+
+// loadGeometry 获取窗口上次关闭时的状态，首次调用时从本地文件加载
+func (wm *windowManager) loadGeometry(windowId int) (WinGeometry, bool) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	wm.ensureGeometries()
+	g, ok := wm.geometries[windowId]
+	if !ok || g.Width <= 0 || g.Height <= 0 {
+		return WinGeometry{}, false
+	}
+	return g, true
+}
+
+// saveGeometry 记录窗口当前状态并写入本地文件
+func (wm *windowManager) saveGeometry(windowId int, window fyne.Window) {
+	size := window.Canvas().Size()
+	if size.Width <= 0 || size.Height <= 0 {
+		return
+	}
+
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	wm.ensureGeometries()
+	wm.geometries[windowId] = WinGeometry{
+		Width:      size.Width,
+		Height:     size.Height,
+		FullScreen: window.FullScreen(),
+	}
+	_ = SaveToLocFile(wm.app, winGeometryFile, wm.geometries)
+}
+
+// ResetGeometry 清除指定窗口记忆的状态，不传 id 时清除全部
+func (wm *windowManager) ResetGeometry(windowIds ...int) error {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	wm.ensureGeometries()
+	if len(windowIds) == 0 {
+		wm.geometries = make(map[int]WinGeometry)
+	} else {
+		for _, id := range windowIds {
+			delete(wm.geometries, id)
+		}
+	}
+	return SaveToLocFile(wm.app, winGeometryFile, wm.geometries)
+}
+
+// ensureGeometries 调用方需持有 wm.mutex
+func (wm *windowManager) ensureGeometries() {
+	if wm.geometries != nil {
+		return
+	}
+
+	wm.geometries = make(map[int]WinGeometry)
+	if wm.app == nil {
+		return
+	}
+
+	if saved, err := LoadFromLocFile[map[int]WinGeometry](wm.app, winGeometryFile); err == nil && *saved != nil {
+		wm.geometries = *saved
+	}
+}
+
+// ResetWindowGeometry 清除记忆的窗口尺寸，下次 ShowPage 时按 Page.WinSize() 重新计算
+func ResetWindowGeometry(windowIds ...int) error {
+	return winManagerIns().ResetGeometry(windowIds...)
+}
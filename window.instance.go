@@ -0,0 +1,139 @@
+package myfyne
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"fyne.io/fyne/v2"
+)
+
+// InstancePlacementFn 多实例页面新窗口显示后的摆放回调，index 为同类页面中的第几个实例（从 0 开始）。
+// fyne 没有设置窗口位置的接口，层叠摆放需要借助 driver.NativeWindow 等平台相关的方式实现
+type InstancePlacementFn func(win fyne.Window, index int)
+
+// pageWindowID 返回页面对应的窗口 id。普通页面直接使用 WinID()；
+// 多实例页面按 类型+WinID()+InstanceKey() 分配独立的窗口 id。
+// alloc 为 false 时只查找不分配；alloc 为 true 且已达到 MaxInstances 上限时返回 false。
+// 调用方需持有 wm.mutex
+func (wm *windowManager) pageWindowID(page Page, alloc bool) (int, bool) {
+	mp, ok := page.(MultiInstancePage)
+	if !ok {
+		return page.WinID(), true
+	}
+
+	class := fmt.Sprintf("%T#%d", page, page.WinID())
+	key := class + "#" + mp.InstanceKey()
+	if id, ok := wm.instances[key]; ok {
+		return id, true
+	}
+
+	if !alloc {
+		return 0, false
+	}
+
+	if max := mp.MaxInstances(); max > 0 && wm.countInstances(class) >= max {
+		return 0, false
+	}
+
+	id := wm.nextAutoID()
+	wm.instances[key] = id
+	wm.instanceKeys[id] = key
+	return id, true
+}
+
+// countInstances 统计同一类页面当前打开的窗口数，调用方需持有 wm.mutex
+func (wm *windowManager) countInstances(class string) int {
+	count := 0
+	for key, id := range wm.instances {
+		if _, open := wm.windows[id]; open && strings.HasPrefix(key, class+"#") {
+			count++
+		}
+	}
+	return count
+}
+
+// winIDOf 查找页面对应的窗口 id
+func (wm *windowManager) winIDOf(page Page) (int, bool) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	return wm.pageWindowID(page, false)
+}
+
+// addPageCloseHook 注册页面窗口关闭时的回调，回调执行一次后即被清除
+func (wm *windowManager) addPageCloseHook(page Page, fn func()) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	if windowId, ok := wm.pageWindowID(page, true); ok {
+		wm.closeHooks[windowId] = append(wm.closeHooks[windowId], fn)
+	}
+}
+
+// SetInstancePlacement 设置多实例页面新窗口的摆放方式，为 nil 时按 centerOnScreen 处理
+func (wm *windowManager) SetInstancePlacement(fn InstancePlacementFn) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	wm.placement = fn
+}
+
+// placeInstance 新打开的多实例页面窗口交给摆放回调处理
+func (wm *windowManager) placeInstance(window fyne.Window, page Page) {
+	if _, ok := page.(MultiInstancePage); !ok {
+		return
+	}
+
+	wm.mutex.Lock()
+	fn := wm.placement
+	index := wm.countInstances(fmt.Sprintf("%T#%d", page, page.WinID())) - 1
+	wm.mutex.Unlock()
+
+	if fn != nil {
+		fn(window, index)
+	}
+}
+
+func SetInstancePlacement(fn InstancePlacementFn) {
+	winManagerIns().SetInstancePlacement(fn)
+}
+
+// addPageHook 按页面对象登记关闭回调，页面的窗口关闭时执行一次；
+// 与 addPageCloseHook 不同，不依赖登记时的 WinID（例如路由页面在工厂函数中订阅消息时 id 尚未分配）。
+// 页面类型不可比较时退回到按窗口 id 登记
+func (wm *windowManager) addPageHook(page Page, fn func()) {
+	if !reflect.TypeOf(page).Comparable() {
+		wm.addPageCloseHook(page, fn)
+		return
+	}
+
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	wm.pageHooks[page] = append(wm.pageHooks[page], fn)
+}
+
+func (wm *windowManager) runPageHooks(page Page) {
+	if !reflect.TypeOf(page).Comparable() {
+		return
+	}
+
+	wm.mutex.Lock()
+	hooks := wm.pageHooks[page]
+	delete(wm.pageHooks, page)
+	wm.mutex.Unlock()
+
+	for _, fn := range hooks {
+		fn()
+	}
+}
+
+// InstanceCount 返回同一类页面当前打开的实例数
+func InstanceCount(page MultiInstancePage) int {
+	wm := winManagerIns()
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	return wm.countInstances(fmt.Sprintf("%T#%d", page, page.WinID()))
+}
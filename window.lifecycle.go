@@ -0,0 +1,166 @@
+package myfyne
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+)
+
+// canClose 依次询问页面的 OnBeforeClose 与传入的拦截函数，任一返回 false 则不关闭
+func (wm *windowManager) canClose(page Page, interceptCloseFn WinWillCloseFn) bool {
+	if p, ok := page.(PageBeforeClose); ok && !p.OnBeforeClose() {
+		return false
+	}
+
+	if interceptCloseFn != nil && !interceptCloseFn() {
+		return false
+	}
+	return true
+}
+
+// focusPage 将焦点切换到指定窗口：原焦点页面 OnBlur，新页面 OnFocus
+func (wm *windowManager) focusPage(windowId int) {
+	wm.mutex.Lock()
+	if wm.focused && wm.focusedID == windowId {
+		wm.mutex.Unlock()
+		return
+	}
+
+	var prev Page
+	if wm.focused {
+		prev = wm.pages[wm.focusedID]
+	}
+	page := wm.pages[windowId]
+	wm.focusedID, wm.focused = windowId, page != nil
+	wm.mutex.Unlock()
+
+	notifyBlur(prev)
+	notifyFocus(page)
+}
+
+// blurPage 页面窗口隐藏或关闭时，如果它持有焦点则触发 OnBlur；
+// 传入窗口 id 而不是按页面查找，关闭时多实例页面的 id 映射可能已被 CloseWindow 清除
+func (wm *windowManager) blurPage(windowId int, page Page) {
+	wm.mutex.Lock()
+	if page == nil || !wm.focused || windowId != wm.focusedID {
+		wm.mutex.Unlock()
+		return
+	}
+
+	wm.focused = false
+	wm.mutex.Unlock()
+
+	notifyBlur(page)
+}
+
+// appEnteredForeground 应用回到前台时，焦点页面重新获得焦点
+func (wm *windowManager) appEnteredForeground() {
+	wm.mutex.Lock()
+	page := wm.focusedPage()
+	wm.mutex.Unlock()
+
+	notifyFocus(page)
+}
+
+// appExitedForeground 应用进入后台时，焦点页面失去焦点
+func (wm *windowManager) appExitedForeground() {
+	wm.mutex.Lock()
+	page := wm.focusedPage()
+	wm.mutex.Unlock()
+
+	notifyBlur(page)
+}
+
+// focusedPage 调用方需持有 wm.mutex
+func (wm *windowManager) focusedPage() Page {
+	if !wm.focused {
+		return nil
+	}
+	return wm.pages[wm.focusedID]
+}
+
+// showPage 窗口由隐藏变为显示时触发 OnShow，已显示的窗口再次 Show 不重复触发
+func (wm *windowManager) showPage(windowId int, page Page) {
+	wm.mutex.Lock()
+	shown := wm.visible[windowId]
+	wm.visible[windowId] = true
+	wm.mutex.Unlock()
+
+	if !shown {
+		notifyShow(page)
+	}
+}
+
+// hidePage 窗口由显示变为隐藏（或关闭）时触发 OnHide
+func (wm *windowManager) hidePage(windowId int, page Page) {
+	wm.mutex.Lock()
+	shown := wm.visible[windowId]
+	delete(wm.visible, windowId)
+	wm.mutex.Unlock()
+
+	if shown {
+		notifyHide(page)
+	}
+}
+
+func notifyShow(page Page) {
+	if p, ok := page.(PageShow); ok {
+		p.OnShow()
+	}
+}
+
+func notifyHide(page Page) {
+	if p, ok := page.(PageHide); ok {
+		p.OnHide()
+	}
+}
+
+func notifyFocus(page Page) {
+	if p, ok := page.(PageFocus); ok {
+		p.OnFocus()
+	}
+}
+
+func notifyBlur(page Page) {
+	if p, ok := page.(PageFocus); ok {
+		p.OnBlur()
+	}
+}
+
+// wrapPageContent 页面实现 PageResize 时，用一个监听尺寸变化的容器包裹页面内容
+func wrapPageContent(page Page) fyne.CanvasObject {
+	content := page.Content()
+	p, ok := page.(PageResize)
+	if !ok {
+		return content
+	}
+
+	return container.New(&resizeLayout{onResize: p.OnResize}, content)
+}
+
+// resizeLayout 撑满父容器，并在尺寸变化时回调
+type resizeLayout struct {
+	lastSize fyne.Size
+	onResize func(size fyne.Size)
+}
+
+func (l *resizeLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	for _, o := range objects {
+		o.Resize(size)
+		o.Move(fyne.NewPos(0, 0))
+	}
+
+	if size != l.lastSize {
+		l.lastSize = size
+		if l.onResize != nil {
+			l.onResize(size)
+		}
+	}
+}
+
+func (l *resizeLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	minSize := fyne.NewSize(0, 0)
+	for _, o := range objects {
+		minSize = minSize.Max(o.MinSize())
+	}
+	return minSize
+}
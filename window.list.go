@@ -0,0 +1,145 @@
+package myfyne
+
+import (
+	"fyne.io/fyne/v2"
+)
+
+// PageInfo 已打开页面的信息
+type PageInfo struct {
+	ID    int
+	Title string
+	Page  Page
+}
+
+// ListPages 按打开顺序返回所有已打开的页面
+func (wm *windowManager) ListPages() []PageInfo {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	return wm.listPages()
+}
+
+// listPages 调用方需持有 wm.mutex
+func (wm *windowManager) listPages() []PageInfo {
+	list := make([]PageInfo, 0, len(wm.order))
+	for _, id := range wm.order {
+		page, ok := wm.pages[id]
+		if !ok {
+			continue
+		}
+
+		title := page.WinTitle()
+		if win, ok := wm.windows[id]; ok && win.Title() != "" {
+			title = win.Title()
+		}
+		list = append(list, PageInfo{ID: id, Title: title, Page: page})
+	}
+	return list
+}
+
+// FocusPage 显示并激活指定窗口，窗口不存在时返回 false
+func (wm *windowManager) FocusPage(windowId int) bool {
+	wm.mutex.Lock()
+	window, ok := wm.windows[windowId]
+	wm.mutex.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	wm.ShowWindow(windowId)
+	window.RequestFocus()
+	return true
+}
+
+// CloseAll 关闭除 except 以外的所有页面，返回因 OnBeforeClose 拒绝而未关闭的页面
+func (wm *windowManager) CloseAll(except ...int) []Page {
+	skip := make(map[int]bool, len(except))
+	for _, id := range except {
+		skip[id] = true
+	}
+
+	var refused []Page
+	for _, info := range wm.ListPages() {
+		if skip[info.ID] {
+			continue
+		}
+
+		wm.ClosePage(info.Page)
+		if wm.GetWindow(info.Page) != nil {
+			refused = append(refused, info.Page)
+		}
+	}
+	return refused
+}
+
+// OnPagesChanged 注册已打开页面集合变化（打开/关闭窗口）时的回调，返回的函数用于取消注册
+func (wm *windowManager) OnPagesChanged(fn func(pages []PageInfo)) (remove func()) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	wm.listenerID++
+	id := wm.listenerID
+	wm.listeners[id] = fn
+	return func() {
+		wm.mutex.Lock()
+		defer wm.mutex.Unlock()
+
+		delete(wm.listeners, id)
+	}
+}
+
+func (wm *windowManager) firePagesChanged() {
+	wm.mutex.Lock()
+	pages := wm.listPages()
+	listeners := make([]func(pages []PageInfo), 0, len(wm.listeners))
+	for _, fn := range wm.listeners {
+		listeners = append(listeners, fn)
+	}
+	wm.mutex.Unlock()
+
+	for _, fn := range listeners {
+		fn(pages)
+	}
+}
+
+// NewWindowMenu 创建列出所有已打开页面的菜单，点击菜单项切换到对应窗口；
+// 菜单会随窗口打开/关闭自动刷新，可直接放入 fyne.MainMenu
+func NewWindowMenu(label string) *fyne.Menu {
+	if label == "" {
+		label = "窗口"
+	}
+
+	menu := fyne.NewMenu(label)
+	update := func(pages []PageInfo) {
+		items := make([]*fyne.MenuItem, 0, len(pages))
+		for _, info := range pages {
+			id := info.ID
+			items = append(items, fyne.NewMenuItem(info.Title, func() {
+				FocusPage(id)
+			}))
+		}
+		menu.Items = items
+		menu.Refresh()
+	}
+
+	update(ListPages())
+	OnPagesChanged(update)
+	return menu
+}
+
+func ListPages() []PageInfo {
+	return winManagerIns().ListPages()
+}
+
+func FocusPage(windowId int) bool {
+	return winManagerIns().FocusPage(windowId)
+}
+
+func CloseAll(except ...int) []Page {
+	return winManagerIns().CloseAll(except...)
+}
+
+func OnPagesChanged(fn func(pages []PageInfo)) (remove func()) {
+	return winManagerIns().OnPagesChanged(fn)
+}
@@ -2,15 +2,37 @@ package myfyne
 
 import (
 	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/dialog"
 	"sync"
 )
 
 // windowManager 是 WindowManager 的单例实现
 type windowManager struct {
-	app     fyne.App
-	windows map[int]fyne.Window
-	mutex   sync.Mutex
+	app          fyne.App
+	windows      map[int]fyne.Window
+	pages        map[int]Page
+	closeHooks   map[int][]func()  //窗口关闭时需要执行的回调
+	pageHooks    map[Page][]func() //按页面对象登记的关闭回调，用于窗口尚未创建（WinID 尚未确定）的页面
+	geometries   map[int]WinGeometry
+	focusedID    int  //当前获得焦点的窗口
+	focused      bool //focusedID 是否有效
+	routes       []*route
+	routePages   map[string]Page //路由+参数 -> 已打开的页面
+	lastAutoID   int             //最近一次自动分配的窗口 id
+	order        []int           //窗口打开顺序
+	listeners    map[int]func(pages []PageInfo)
+	listenerID   int
+	intercepts   map[int]WinWillCloseFn //ShowPage 时传入的关闭拦截函数
+	mainID       int                    //主页面窗口 id，关闭主窗口时走退出流程
+	hasMain      bool
+	quitting     bool
+	instances    map[string]int //多实例页面：类型+WinID+InstanceKey -> 窗口 id
+	instanceKeys map[int]string
+	trayEnabled  bool
+	trayHidden   []int                 //按隐藏顺序记录收起到托盘的窗口
+	modals       map[int][]fyne.Window //父窗口 id -> 其上打开的模态窗口，按打开顺序
+	placement    InstancePlacementFn   //多实例页面新窗口的摆放方式
+	visible      map[int]bool          //窗口当前是否显示，OnShow/OnHide 只在状态变化时触发
+	mutex        sync.Mutex
 }
 
 var instance *windowManager
@@ -20,7 +42,17 @@ var once sync.Once
 func winManagerIns() *windowManager {
 	once.Do(func() {
 		instance = &windowManager{
-			windows: make(map[int]fyne.Window),
+			windows:      make(map[int]fyne.Window),
+			pages:        make(map[int]Page),
+			closeHooks:   make(map[int][]func()),
+			pageHooks:    make(map[Page][]func()),
+			routePages:   make(map[string]Page),
+			listeners:    make(map[int]func(pages []PageInfo)),
+			intercepts:   make(map[int]WinWillCloseFn),
+			instances:    make(map[string]int),
+			instanceKeys: make(map[int]string),
+			modals:       make(map[int][]fyne.Window),
+			visible:      make(map[int]bool),
 		}
 	})
 	return instance
@@ -33,6 +65,8 @@ func (wm *windowManager) SetApp(app fyne.App) {
 
 	if wm.app == nil {
 		wm.app = app
+		app.Lifecycle().SetOnEnteredForeground(wm.appEnteredForeground)
+		app.Lifecycle().SetOnExitedForeground(wm.appExitedForeground)
 	}
 }
 
@@ -48,36 +82,63 @@ func (wm *windowManager) ShowPage(page Page, centerOnScreen bool, fixedSize bool
 	if wm.app == nil {
 		panic("App instance not set. Use SetApp to initialize.")
 	}
-	windowID := page.WinID()
 
 	// 检查窗口是否已经存在
 	wm.mutex.Lock()
+	windowID, ok := wm.pageWindowID(page, true)
+	if !ok {
+		wm.mutex.Unlock()
+		fyne.LogError("too many instances of page: "+page.WinTitle(), nil)
+		return
+	}
 	window, exists := wm.windows[windowID]
 	if !exists {
 		// 创建新窗口
 		window = wm.app.NewWindow("")
 		wm.windows[windowID] = window
+		wm.order = append(wm.order, windowID)
 	}
+	wm.pages[windowID] = page
+	wm.intercepts[windowID] = interceptCloseFn
+	isMain := wm.hasMain && wm.mainID == windowID
 	wm.mutex.Unlock()
 
-	if interceptCloseFn != nil {
-		window.SetCloseIntercept(func() {
-			if interceptCloseFn() {
-				window.Close()
-			}
-		})
-	} else {
-		window.SetCloseIntercept(nil)
+	if isMain {
+		window.SetMaster()
 	}
 
+	window.SetCloseIntercept(func() {
+		if modal := wm.topModal(windowID); modal != nil { //模态页面未关闭前父窗口不能关闭
+			modal.RequestFocus()
+			return
+		}
+
+		if wm.hideToTray(windowID, page) {
+			return
+		}
+
+		if isMain {
+			wm.Quit()
+		} else if wm.canClose(page, interceptCloseFn) {
+			window.Close()
+		}
+	})
+
 	window.SetOnClosed(func() { //在窗口关闭时。要清掉这个window，不然，下次显示就不会生效了
+		wm.blurPage(windowID, page)
+		wm.hidePage(windowID, page)
 		page.WinClosed() //页面清除处理
+		if wm.rememberGeometry(page, fixedSize) {
+			wm.saveGeometry(page.WinID(), window)
+		}
 		wm.CloseWindow(windowID)
+		wm.runCloseHooks(windowID)
+		wm.runPageHooks(page)
 		window = nil
 	})
 
 	// 设置页面内容并调整窗口大小
-	window.SetContent(page.Content())
+	window.SetContent(wrapPageContent(page))
 	window.SetTitle(page.WinTitle())
 
 	winSize := page.WinSize()
@@ -89,8 +150,19 @@ func (wm *windowManager) ShowPage(page Page, centerOnScreen bool, fixedSize bool
 		winSize.Height = page.Content().MinSize().Height
 	}
 
+	fullScreen := false
+	if wm.rememberGeometry(page, fixedSize) {
+		if g, ok := wm.loadGeometry(page.WinID()); ok { //多实例页面共用同一份尺寸记录
+			winSize = fyne.NewSize(g.Width, g.Height)
+			fullScreen = g.FullScreen
+		}
+	}
+
 	window.Resize(winSize)
 	window.SetFixedSize(fixedSize)
+	if fullScreen {
+		window.SetFullScreen(true)
+	}
 
 	if isModel {
 		if centerOnScreen {
@@ -100,6 +172,14 @@ func (wm *windowManager) ShowPage(page Page, centerOnScreen bool, fixedSize bool
 			//	go window.CenterOnScreen()
 			//}
 		}
+		if !exists {
+			wm.placeInstance(window, page)
+		}
+		wm.showPage(windowID, page)
+		wm.focusPage(windowID)
+		if !exists {
+			wm.firePagesChanged()
+		}
 		window.ShowAndRun() // 注意：阻塞式
 	} else {
 		window.Show()
@@ -110,23 +190,87 @@ func (wm *windowManager) ShowPage(page Page, centerOnScreen bool, fixedSize bool
 			//	go window.CenterOnScreen()
 			//}
 		}
+		if !exists {
+			wm.placeInstance(window, page)
+		}
+		wm.showPage(windowID, page)
+		wm.focusPage(windowID)
+		if !exists {
+			wm.firePagesChanged()
+		}
+	}
+}
+
+// ShowModalPage 以模态方式显示页面：立即返回，父页面窗口在子页面关闭前不可交互（包括关闭按钮与快捷键），
+// 子页面关闭时通过 onClose 回传结果（页面实现 PageResult 时为 GetCloseParam 的返回值）；
+// 页面已打开时只激活其窗口，不重复添加遮罩；页面未能打开（例如超过多实例上限）时立即以 nil 回调
+func (wm *windowManager) ShowModalPage(parent Page, page Page, centerOnScreen bool, fixedSize bool, interceptCloseFn WinWillCloseFn, onClose func(param any)) {
+	opened := wm.GetWindow(page) != nil
+	wm.ShowPage(page, centerOnScreen, fixedSize, false, interceptCloseFn)
+	window := wm.GetWindow(page)
+	if window == nil {
+		if onClose != nil {
+			onClose(nil)
+		}
+		return
 	}
+
+	var parentWin fyne.Window
+	var parentID int
+	if parent != nil && !opened {
+		if id, ok := wm.winIDOf(parent); ok && wm.GetWindow(parent) != window {
+			parentWin, parentID = wm.GetWindow(parent), id
+		}
+	}
+
+	var mask *modalMask
+	if parentWin != nil {
+		mask = newModalMask(window)
+		parentWin.Canvas().Overlays().Add(mask)
+		mask.Resize(parentWin.Canvas().Size())
+		parentWin.Canvas().Focus(mask) //焦点在遮罩上时，父窗口的快捷键与键盘输入都被遮罩吞掉
+		wm.pushModal(parentID, window)
+	}
+
+	wm.addPageCloseHook(page, func() {
+		if mask != nil {
+			wm.popModal(parentID, window)
+			parentWin.Canvas().Overlays().Remove(mask)
+			parentWin.RequestFocus()
+			if id, ok := wm.winIDOf(parent); ok {
+				wm.focusPage(id)
+			}
+		}
+
+		if onClose != nil {
+			var param any
+			if p, ok := page.(PageResult); ok {
+				param = p.GetCloseParam()
+			}
+			onClose(param)
+		}
+	})
 }
 
-// ClosePage 关闭页面对应的窗口
+// ClosePage 关闭页面对应的窗口，页面实现 PageBeforeClose 且返回 false 时不关闭
 func (wm *windowManager) ClosePage(page Page) {
 	wm.mutex.Lock()
-	defer wm.mutex.Unlock()
-
 	if wm.app == nil {
+		wm.mutex.Unlock()
 		panic("App instance not set. Use SetApp to initialize.")
 	}
 
-	windowID := page.WinID()
-	if window, exists := wm.windows[windowID]; exists {
-		window.Close()
-		delete(wm.windows, windowID)
+	windowID, _ := wm.pageWindowID(page, false)
+	window, exists := wm.windows[windowID]
+	wm.mutex.Unlock()
+
+	if !exists || !wm.canClose(page, nil) {
+		return
 	}
+
+	//window.Close 会同步触发 SetOnClosed 回调，不能在持锁时调用
+	wm.CloseWindow(windowID)
+	window.Close()
 }
 
 // GetWindow 获取页面对应的窗口
@@ -134,48 +278,91 @@ func (wm *windowManager) GetWindow(page Page) fyne.Window {
 	wm.mutex.Lock()
 	defer wm.mutex.Unlock()
 
-	return wm.windows[page.WinID()]
+	windowID, ok := wm.pageWindowID(page, false)
+	if !ok {
+		return nil
+	}
+	return wm.windows[windowID]
 }
 
 // ShowWindow 显示指定页面的窗口
 func (wm *windowManager) ShowWindow(windowId int) {
 	wm.mutex.Lock()
-	defer wm.mutex.Unlock()
-
 	if wm.app == nil {
+		wm.mutex.Unlock()
 		panic("App instance not set. Use SetApp to initialize.")
 	}
 
-	if window, ok := wm.windows[windowId]; ok {
+	window, ok := wm.windows[windowId]
+	page := wm.pages[windowId]
+	wm.mutex.Unlock()
+
+	if ok {
 		window.Show()
+		wm.showPage(windowId, page)
+		wm.focusPage(windowId)
 	}
 }
 
 // HideWindow 隐藏指定页面的窗口
 func (wm *windowManager) HideWindow(windowId int) {
 	wm.mutex.Lock()
-	defer wm.mutex.Unlock()
-
 	if wm.app == nil {
+		wm.mutex.Unlock()
 		panic("App instance not set. Use SetApp to initialize.")
 	}
 
-	if window, ok := wm.windows[windowId]; ok {
+	window, ok := wm.windows[windowId]
+	page := wm.pages[windowId]
+	wm.mutex.Unlock()
+
+	if ok {
 		window.Hide()
+		wm.blurPage(windowId, page)
+		wm.hidePage(windowId, page)
 	}
 }
 
 // 当关闭窗口时，清掉一些
 func (wm *windowManager) CloseWindow(windowId int) {
 	wm.mutex.Lock()
-	defer wm.mutex.Unlock()
-
 	if wm.app == nil {
+		wm.mutex.Unlock()
 		panic("App instance not set. Use SetApp to initialize.")
 	}
 
-	if _, ok := wm.windows[windowId]; ok {
+	_, ok := wm.windows[windowId]
+	if ok {
 		delete(wm.windows, windowId)
+		for i, id := range wm.order {
+			if id == windowId {
+				wm.order = append(wm.order[:i], wm.order[i+1:]...)
+				break
+			}
+		}
+	}
+	delete(wm.pages, windowId)
+	delete(wm.intercepts, windowId)
+	wm.removeTrayHidden(windowId)
+	if key, ok := wm.instanceKeys[windowId]; ok {
+		delete(wm.instanceKeys, windowId)
+		delete(wm.instances, key)
+	}
+	wm.mutex.Unlock()
+
+	if ok {
+		wm.firePagesChanged()
+	}
+}
+
+func (wm *windowManager) runCloseHooks(windowId int) {
+	wm.mutex.Lock()
+	hooks := wm.closeHooks[windowId]
+	delete(wm.closeHooks, windowId)
+	wm.mutex.Unlock()
+
+	for _, fn := range hooks {
+		fn()
 	}
 }
 
@@ -191,10 +378,27 @@ func ShowPage(page Page, centerOnScreen bool, fixedSize bool, interceptCloseFn W
 	winManagerIns().ShowPage(page, centerOnScreen, fixedSize, false, interceptCloseFn)
 }
 
+// ShowModelPage 阻塞式显示页面（内部调用 ShowAndRun），只适用于启动应用时显示第一个窗口；
+// 应用运行后打开模态页面请使用 ShowModalPage
 func ShowModelPage(page Page, centerOnScreen bool, fixedSize bool, interceptCloseFn WinWillCloseFn) {
 	winManagerIns().ShowPage(page, centerOnScreen, fixedSize, true, interceptCloseFn)
 }
 
+// ShowModalPage 以模态方式显示页面并立即返回，页面关闭时结果写入返回的 channel，页面未能打开时写入 nil
+func ShowModalPage(parent Page, page Page, centerOnScreen bool, fixedSize bool, interceptCloseFn WinWillCloseFn) <-chan any {
+	ch := make(chan any, 1)
+	winManagerIns().ShowModalPage(parent, page, centerOnScreen, fixedSize, interceptCloseFn, func(param any) {
+		ch <- param
+		close(ch)
+	})
+	return ch
+}
+
+// ShowModalPageWithCallback 以模态方式显示页面并立即返回，页面关闭时在主线程回调 onClose
+func ShowModalPageWithCallback(parent Page, page Page, centerOnScreen bool, fixedSize bool, interceptCloseFn WinWillCloseFn, onClose func(param any)) {
+	winManagerIns().ShowModalPage(parent, page, centerOnScreen, fixedSize, interceptCloseFn, onClose)
+}
+
 func ClosePage(page Page) {
 	winManagerIns().ClosePage(page)
 }
@@ -212,9 +416,9 @@ func HideWindow(windowId int) {
 }
 
 func ShowError(err error, page Page) {
-	dialog.ShowError(err, winManagerIns().GetWindow(page))
+	ShowErrorQueued(winManagerIns().GetWindow(page), err)
 }
 
 func ShowInfo(title, message string, page Page) {
-	dialog.ShowInformation(title, message, winManagerIns().GetWindow(page))
+	ShowInfoQueued(winManagerIns().GetWindow(page), title, message)
 }
@@ -0,0 +1,89 @@
+package myfyne
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/widget"
+)
+
+// modalMask 覆盖在父窗口上的遮罩，拦截所有点击与键盘输入，点击时把焦点交还给模态窗口
+type modalMask struct {
+	widget.BaseWidget
+	target fyne.Window
+}
+
+func newModalMask(target fyne.Window) *modalMask {
+	m := &modalMask{target: target}
+	m.ExtendBaseWidget(m)
+	return m
+}
+
+func (m *modalMask) CreateRenderer() fyne.WidgetRenderer {
+	bg := canvas.NewRectangle(color.NRGBA{A: 0x40})
+	return widget.NewSimpleRenderer(bg)
+}
+
+func (m *modalMask) Tapped(_ *fyne.PointEvent) {
+	m.target.RequestFocus()
+}
+
+func (m *modalMask) TappedSecondary(_ *fyne.PointEvent) {
+	m.target.RequestFocus()
+}
+
+func (m *modalMask) DoubleTapped(_ *fyne.PointEvent) {
+	m.target.RequestFocus()
+}
+
+// Scrolled 吞掉滚动事件，避免父窗口内容被滚动
+func (m *modalMask) Scrolled(_ *fyne.ScrollEvent) {}
+
+func (m *modalMask) FocusGained() {}
+
+func (m *modalMask) FocusLost() {}
+
+func (m *modalMask) TypedRune(_ rune) {}
+
+func (m *modalMask) TypedKey(_ *fyne.KeyEvent) {}
+
+// TypedShortcut 吞掉快捷键，避免触发父窗口画布上注册的快捷键
+func (m *modalMask) TypedShortcut(_ fyne.Shortcut) {}
+
+// pushModal 记录父窗口上打开的模态窗口
+func (wm *windowManager) pushModal(parentId int, window fyne.Window) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	wm.modals[parentId] = append(wm.modals[parentId], window)
+}
+
+func (wm *windowManager) popModal(parentId int, window fyne.Window) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	list := wm.modals[parentId]
+	for i, w := range list {
+		if w == window {
+			list = append(list[:i], list[i+1:]...)
+			break
+		}
+	}
+	if len(list) == 0 {
+		delete(wm.modals, parentId)
+	} else {
+		wm.modals[parentId] = list
+	}
+}
+
+// topModal 父窗口上最近打开的模态窗口，没有时返回 nil
+func (wm *windowManager) topModal(parentId int) fyne.Window {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	if list := wm.modals[parentId]; len(list) > 0 {
+		return list[len(list)-1]
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package myfyne
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+)
+
+// SetMainPage 设置主页面，需在 ShowPage 之前调用；
+// 主页面窗口关闭时不会直接销毁其它窗口，而是走 Quit 的退出流程
+func (wm *windowManager) SetMainPage(page Page) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	wm.mainID, wm.hasMain = wm.pageWindowID(page, true)
+}
+
+// Quit 退出应用：按打开顺序依次询问每个页面的关闭拦截（OnBeforeClose 以及 ShowPage 传入的拦截函数），
+// 任一页面拒绝则取消退出并切换到该页面；之后如有未保存的页面，弹出汇总对话框确认，全部同意后才退出
+func (wm *windowManager) Quit() {
+	wm.mutex.Lock()
+	if wm.quitting {
+		wm.mutex.Unlock()
+		return
+	}
+	wm.quitting = true
+	pages := wm.listPages()
+	intercepts := make(map[int]WinWillCloseFn, len(wm.intercepts))
+	for id, fn := range wm.intercepts {
+		intercepts[id] = fn
+	}
+	wm.mutex.Unlock()
+
+	for _, info := range pages {
+		if !wm.canClose(info.Page, intercepts[info.ID]) {
+			wm.cancelQuit()
+			wm.FocusPage(info.ID)
+			return
+		}
+	}
+
+	var unsaved []string
+	for _, info := range pages {
+		if p, ok := info.Page.(PageUnsaved); ok && p.HasUnsavedChanges() {
+			unsaved = append(unsaved, "· "+info.Title)
+		}
+	}
+
+	parent := wm.quitDialogParent()
+	if len(unsaved) == 0 || parent == nil {
+		wm.quitNow(pages)
+		return
+	}
+
+	msg := "以下页面有未保存的内容，确定要退出吗？\n\n" + strings.Join(unsaved, "\n")
+	dialog.ShowConfirm("退出", msg, func(ok bool) {
+		if !ok {
+			wm.cancelQuit()
+			return
+		}
+		wm.quitNow(pages)
+	}, parent)
+}
+
+func (wm *windowManager) cancelQuit() {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	wm.quitting = false
+}
+
+// quitDialogParent 优先使用主页面窗口，其次是当前焦点窗口
+func (wm *windowManager) quitDialogParent() fyne.Window {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	if wm.hasMain {
+		if win, ok := wm.windows[wm.mainID]; ok {
+			return win
+		}
+	}
+
+	if wm.focused {
+		if win, ok := wm.windows[wm.focusedID]; ok {
+			return win
+		}
+	}
+
+	if len(wm.order) > 0 {
+		return wm.windows[wm.order[0]]
+	}
+	return nil
+}
+
+// quitNow 不再询问，按打开的逆序关闭所有窗口后退出应用
+func (wm *windowManager) quitNow(pages []PageInfo) {
+	for i := len(pages) - 1; i >= 0; i-- {
+		wm.mutex.Lock()
+		window, ok := wm.windows[pages[i].ID]
+		wm.mutex.Unlock()
+
+		if ok {
+			wm.CloseWindow(pages[i].ID)
+			window.Close()
+		}
+	}
+
+	wm.cancelQuit()
+	wm.app.Quit()
+}
+
+func SetMainPage(page Page) {
+	winManagerIns().SetMainPage(page)
+}
+
+// QuitApp 走统一的退出流程，所有页面同意后才退出应用
+func QuitApp() {
+	winManagerIns().Quit()
+}
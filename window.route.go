@@ -0,0 +1,198 @@
+package myfyne
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+)
+
+type (
+	// RouteParams 路由参数，包含路径中 ":name" 段解析出的值以及 Open 时传入的参数
+	RouteParams map[string]string
+
+	// PageFactory 根据路由参数创建页面
+	PageFactory func(params RouteParams) Page
+
+	// PageIDSetter 可选接口：通过路由打开的页面 WinID() 为 0 时，由窗口管理器自动分配 id
+	// mywidget.BasePage 已实现该接口
+	PageIDSetter interface {
+		SetWinID(id int)
+	}
+
+	route struct {
+		pattern        string
+		segments       []string
+		centerOnScreen bool
+		fixedSize      bool
+		factory        PageFactory
+	}
+)
+
+// RegisterRoute 注册路由，pattern 形如 "orders/detail/:id"，同一 pattern 重复注册时覆盖
+func (wm *windowManager) RegisterRoute(pattern string, centerOnScreen bool, fixedSize bool, factory PageFactory) {
+	if factory == nil {
+		panic("route factory is nil: " + pattern)
+	}
+
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	r := &route{
+		pattern:        pattern,
+		segments:       splitRoute(pattern),
+		centerOnScreen: centerOnScreen,
+		fixedSize:      fixedSize,
+		factory:        factory,
+	}
+	for i := range wm.routes {
+		if wm.routes[i].pattern == pattern {
+			wm.routes[i] = r
+			return
+		}
+	}
+	wm.routes = append(wm.routes, r)
+}
+
+// Open 按路由打开页面，相同路由+参数的页面已经打开时直接显示已有窗口
+func (wm *windowManager) Open(path string, params RouteParams) (Page, error) {
+	if wm.app == nil {
+		panic("App instance not set. Use SetApp to initialize.")
+	}
+
+	wm.mutex.Lock()
+	r, merged := wm.matchRoute(path, params)
+	if r == nil {
+		wm.mutex.Unlock()
+		return nil, fmt.Errorf("route not found: %s", path)
+	}
+
+	key := routeKey(r.pattern, merged)
+	if page, ok := wm.routePages[key]; ok {
+		windowID, _ := wm.pageWindowID(page, false)
+		if window, exists := wm.windows[windowID]; exists {
+			wm.mutex.Unlock()
+			wm.ShowWindow(windowID)
+			window.RequestFocus()
+			return page, nil
+		}
+		delete(wm.routePages, key)
+	}
+	wm.mutex.Unlock()
+
+	page := r.factory(merged)
+	if page == nil {
+		return nil, fmt.Errorf("route factory returned nil page: %s", path)
+	}
+
+	if setter, ok := page.(PageIDSetter); ok && page.WinID() == 0 {
+		setter.SetWinID(wm.allocWinID())
+	}
+
+	wm.mutex.Lock()
+	wm.routePages[key] = page
+	wm.mutex.Unlock()
+
+	wm.ShowPage(page, r.centerOnScreen, r.fixedSize, false, nil)
+	if wm.GetWindow(page) == nil {
+		wm.mutex.Lock()
+		delete(wm.routePages, key)
+		wm.mutex.Unlock()
+		return nil, fmt.Errorf("open route failed: %s", path)
+	}
+
+	wm.addPageCloseHook(page, func() {
+		wm.mutex.Lock()
+		defer wm.mutex.Unlock()
+
+		if wm.routePages[key] == page {
+			delete(wm.routePages, key)
+		}
+	})
+	return page, nil
+}
+
+// matchRoute 调用方需持有 wm.mutex，按注册顺序返回第一个匹配的路由
+func (wm *windowManager) matchRoute(path string, params RouteParams) (*route, RouteParams) {
+	segments := splitRoute(path)
+	for _, r := range wm.routes {
+		if len(r.segments) != len(segments) {
+			continue
+		}
+
+		merged := RouteParams{}
+		matched := true
+		for i, seg := range r.segments {
+			if strings.HasPrefix(seg, ":") {
+				merged[seg[1:]] = segments[i]
+			} else if seg != segments[i] {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			for k, v := range params {
+				if _, ok := merged[k]; !ok {
+					merged[k] = v
+				}
+			}
+			return r, merged
+		}
+	}
+	return nil, nil
+}
+
+// allocWinID 分配一个自动生成的窗口 id，使用负数避免与业务中手写的 id 冲突
+func (wm *windowManager) allocWinID() int {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	return wm.nextAutoID()
+}
+
+// nextAutoID 调用方需持有 wm.mutex
+func (wm *windowManager) nextAutoID() int {
+	wm.lastAutoID--
+	return wm.lastAutoID
+}
+
+func splitRoute(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// routeKey 路由 pattern 加排序后的参数，用于判断页面是否已经打开
+func routeKey(pattern string, params RouteParams) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	sb.WriteString(pattern)
+	for _, k := range keys {
+		sb.WriteString("&" + k + "=" + params[k])
+	}
+	return sb.String()
+}
+
+// RegisterRoute 注册路由页面
+func RegisterRoute(pattern string, centerOnScreen bool, fixedSize bool, factory PageFactory) {
+	winManagerIns().RegisterRoute(pattern, centerOnScreen, fixedSize, factory)
+}
+
+// Open 按路由打开页面，例如 Open("orders/detail/42", nil)
+func Open(path string, params RouteParams) (Page, error) {
+	return winManagerIns().Open(path, params)
+}
+
+// RouteTapCb 生成打开路由的回调，可直接赋值给 MenuItemModel.OnTapCb
+func RouteTapCb(path string, params RouteParams) func(name string) {
+	return func(name string) {
+		if _, err := Open(path, params); err != nil {
+			fyne.LogError("open route failed", err)
+		}
+	}
+}
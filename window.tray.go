@@ -0,0 +1,135 @@
+package myfyne
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+// EnableTray 启用系统托盘：设置托盘图标与菜单，菜单末尾追加「显示窗口」与「退出」，并开启托盘模式（见 SetTrayMode）。
+// 不使用 desktop.App.SetSystemTrayWindow：它会覆盖窗口的关闭拦截并绕过 RestoreFromTray，收起的窗口统一通过菜单恢复。
+// 当前 app 不是桌面端（例如移动端或 fyne 的测试 app）时不做任何处理并返回 false，
+// 此时实现 PageTray 的页面关闭时仍按普通方式关闭
+func (wm *windowManager) EnableTray(icon fyne.Resource, items []MenuItemModel) bool {
+	wm.mutex.Lock()
+	deskApp, ok := wm.app.(desktop.App)
+	wm.mutex.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	menu := NewMenuFromModel("", items)
+	if len(menu.Items) > 0 {
+		menu.Items = append(menu.Items, fyne.NewMenuItemSeparator())
+	}
+	menu.Items = append(menu.Items, fyne.NewMenuItem("显示窗口", func() {
+		wm.RestoreFromTray()
+	}))
+	quitItem := fyne.NewMenuItem("退出", QuitApp)
+	quitItem.IsQuit = true
+	menu.Items = append(menu.Items, quitItem)
+
+	if icon != nil {
+		deskApp.SetSystemTrayIcon(icon)
+	}
+	deskApp.SetSystemTrayMenu(menu)
+	wm.SetTrayMode(true)
+	return true
+}
+
+// SetTrayMode 开启/关闭托盘模式：开启后实现 PageTray 的页面关闭窗口时只隐藏，通过 RestoreFromTray 恢复；
+// 只负责窗口的隐藏与恢复，不安装系统托盘图标，一般由 EnableTray 调用。关闭时恢复所有已收起的窗口
+func (wm *windowManager) SetTrayMode(enabled bool) {
+	wm.mutex.Lock()
+	wm.trayEnabled = enabled
+	wm.mutex.Unlock()
+
+	if enabled {
+		return
+	}
+	for wm.RestoreFromTray() {
+	}
+}
+
+// RestoreFromTray 恢复最近一次收起到托盘的页面，没有时返回 false
+func (wm *windowManager) RestoreFromTray() bool {
+	wm.mutex.Lock()
+	if len(wm.trayHidden) == 0 {
+		wm.mutex.Unlock()
+		return false
+	}
+	windowId := wm.trayHidden[len(wm.trayHidden)-1]
+	wm.trayHidden = wm.trayHidden[:len(wm.trayHidden)-1]
+	wm.mutex.Unlock()
+
+	return wm.FocusPage(windowId)
+}
+
+// hideToTray 托盘已启用且页面实现 PageTray 返回 true 时隐藏窗口并记录，返回是否已处理
+func (wm *windowManager) hideToTray(windowId int, page Page) bool {
+	wm.mutex.Lock()
+	enabled := wm.trayEnabled && !wm.quitting
+	wm.mutex.Unlock()
+
+	p, ok := page.(PageTray)
+	if !enabled || !ok || !p.HideToTray() {
+		return false
+	}
+
+	wm.HideWindow(windowId)
+	wm.mutex.Lock()
+	wm.removeTrayHidden(windowId)
+	wm.trayHidden = append(wm.trayHidden, windowId)
+	wm.mutex.Unlock()
+
+	return true
+}
+
+// removeTrayHidden 调用方需持有 wm.mutex
+func (wm *windowManager) removeTrayHidden(windowId int) {
+	for i, id := range wm.trayHidden {
+		if id == windowId {
+			wm.trayHidden = append(wm.trayHidden[:i], wm.trayHidden[i+1:]...)
+			return
+		}
+	}
+}
+
+// NewMenuFromModel 将 MenuItemModel 树转换为 fyne.Menu，隐藏的菜单项会被忽略
+func NewMenuFromModel(label string, items []MenuItemModel) *fyne.Menu {
+	return fyne.NewMenu(label, menuItemsFromModel(items)...)
+}
+
+func menuItemsFromModel(items []MenuItemModel) []*fyne.MenuItem {
+	list := make([]*fyne.MenuItem, 0, len(items))
+	for i := range items {
+		model := items[i]
+		if model.IsHidden {
+			continue
+		}
+
+		item := fyne.NewMenuItem(model.Name, func() {
+			if model.OnTapCb != nil {
+				model.OnTapCb(model.Name)
+			}
+		})
+		item.Icon = model.Icon
+		if len(model.SubItems) > 0 {
+			item.ChildMenu = NewMenuFromModel("", model.SubItems)
+		}
+		list = append(list, item)
+	}
+	return list
+}
+
+func EnableTray(icon fyne.Resource, items []MenuItemModel) bool {
+	return winManagerIns().EnableTray(icon, items)
+}
+
+func SetTrayMode(enabled bool) {
+	winManagerIns().SetTrayMode(enabled)
+}
+
+func RestoreFromTray() bool {
+	return winManagerIns().RestoreFromTray()
+}
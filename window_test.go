@@ -1,8 +1,696 @@
 package myfyne
 
-import "testing"
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+type testPage struct {
+	id         int
+	title      string
+	closeParam any
+}
+
+func (p *testPage) Content() fyne.CanvasObject { return widget.NewLabel(p.title) }
+func (p *testPage) WinTitle() string           { return p.title }
+func (p *testPage) WinID() int                 { return p.id }
+func (p *testPage) WinSize() fyne.Size         { return fyne.NewSize(200, 100) }
+func (p *testPage) WinClosed()                 {}
+func (p *testPage) GetCloseParam() any         { return p.closeParam }
 
 func TestWindowManager_HideWindow(t *testing.T) {
 	winManagerIns()
 	t.Log("aa")
 }
+
+func TestWindowManager_ShowModalPage(t *testing.T) {
+	SetApp(test.NewApp())
+
+	parent := &testPage{id: 1001, title: "parent"}
+	child := &testPage{id: 1002, title: "child"}
+	ShowPage(parent, false, false, nil)
+	parentWin := GetWindows(parent)
+
+	ch := ShowModalPage(parent, child, false, false, nil)
+	if len(parentWin.Canvas().Overlays().List()) != 1 {
+		t.Fatal("parent window should be masked while modal page is open")
+	}
+	if any(parentWin.Canvas().Focused()) != any(parentWin.Canvas().Overlays().Top()) {
+		t.Fatal("mask should take the parent's keyboard focus")
+	}
+	ShowModalPage(parent, child, false, false, nil)
+	if len(parentWin.Canvas().Overlays().List()) != 1 {
+		t.Fatal("showing an open modal page again should not add another mask")
+	}
+
+	child.closeParam = "ok"
+	ClosePage(child)
+
+	select {
+	case v := <-ch:
+		if v != "ok" {
+			t.Fatalf("unexpected modal result: %v", v)
+		}
+	default:
+		t.Fatal("modal result not delivered")
+	}
+
+	if len(parentWin.Canvas().Overlays().List()) != 0 {
+		t.Fatal("parent mask should be removed after modal page closed")
+	}
+	ClosePage(parent)
+}
+
+func TestWindowManager_ShowModalPage_Refused(t *testing.T) {
+	SetApp(test.NewApp())
+
+	parent := &testPage{id: 1010, title: "parent"}
+	ShowPage(parent, false, false, nil)
+	defer ClosePage(parent)
+
+	o1 := &orderPage{testPage: testPage{id: 3001, title: "order"}, orderNo: "A"}
+	o2 := &orderPage{testPage: testPage{id: 3001, title: "order"}, orderNo: "B"}
+	o3 := &orderPage{testPage: testPage{id: 3001, title: "order"}, orderNo: "C"}
+	ShowPage(o1, false, false, nil)
+	ShowPage(o2, false, false, nil)
+	defer CloseAll()
+
+	ch := ShowModalPage(parent, o3, false, false, nil)
+	if v := <-ch; v != nil {
+		t.Fatalf("refused modal page should deliver nil: %v", v)
+	}
+	if _, ok := <-ch; ok {
+		t.Fatal("refused modal page should close the channel")
+	}
+	if len(GetWindows(parent).Canvas().Overlays().List()) != 0 {
+		t.Fatal("refused modal page should not mask the parent")
+	}
+}
+
+func TestWindowManager_RestoreGeometry(t *testing.T) {
+	SetApp(test.NewApp())
+	defer ResetWindowGeometry()
+
+	page := &testPage{id: 1003, title: "geometry"}
+	ShowPage(page, false, false, nil)
+	GetWindows(page).Resize(fyne.NewSize(320, 240))
+	ClosePage(page)
+
+	ShowPage(page, false, false, nil)
+	defer ClosePage(page)
+	if size := GetWindows(page).Canvas().Size(); size != fyne.NewSize(320, 240) {
+		t.Fatalf("window size not restored: %v", size)
+	}
+}
+
+func TestWindowManager_RouteGeometryNotSaved(t *testing.T) {
+	SetApp(test.NewApp())
+	defer ResetWindowGeometry()
+
+	RegisterRoute("geometry/:id", false, false, func(params RouteParams) Page {
+		return &autoIDPage{testPage: testPage{title: "geometry " + params["id"]}}
+	})
+	page, err := Open("geometry/1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.WinID() >= 0 {
+		t.Fatalf("route page should get an auto id: %d", page.WinID())
+	}
+
+	GetWindows(page).Resize(fyne.NewSize(320, 240))
+	ClosePage(page)
+	if _, ok := winManagerIns().loadGeometry(page.WinID()); ok {
+		t.Fatal("geometry of auto-allocated ids should not be persisted")
+	}
+}
+
+type autoIDPage struct {
+	testPage
+}
+
+func (p *autoIDPage) SetWinID(id int) { p.id = id }
+
+type lifecyclePage struct {
+	testPage
+	events   []string
+	canClose bool
+}
+
+func (p *lifecyclePage) OnShow()             { p.events = append(p.events, "show") }
+func (p *lifecyclePage) OnHide()             { p.events = append(p.events, "hide") }
+func (p *lifecyclePage) OnFocus()            { p.events = append(p.events, "focus") }
+func (p *lifecyclePage) OnBlur()             { p.events = append(p.events, "blur") }
+func (p *lifecyclePage) OnBeforeClose() bool { return p.canClose }
+
+func TestWindowManager_Lifecycle(t *testing.T) {
+	SetApp(test.NewApp())
+
+	page := &lifecyclePage{testPage: testPage{id: 1004, title: "lifecycle"}}
+	ShowPage(page, false, false, nil)
+	ShowPage(page, false, false, nil) // 已显示的窗口不重复触发 OnShow
+	FocusPage(page.WinID())
+	HideWindow(page.WinID())
+	HideWindow(page.WinID())
+	ShowWindow(page.WinID())
+	ShowWindow(page.WinID())
+
+	ClosePage(page)
+	if GetWindows(page) == nil {
+		t.Fatal("OnBeforeClose veto ignored")
+	}
+
+	page.canClose = true
+	ClosePage(page)
+	if GetWindows(page) != nil {
+		t.Fatal("page window should be closed")
+	}
+
+	want := []string{"show", "focus", "blur", "hide", "show", "focus", "blur", "hide"}
+	if len(page.events) != len(want) {
+		t.Fatalf("unexpected lifecycle events: %v", page.events)
+	}
+	for i := range want {
+		if page.events[i] != want[i] {
+			t.Fatalf("unexpected lifecycle events: %v", page.events)
+		}
+	}
+}
+
+func TestWindowManager_OpenRoute(t *testing.T) {
+	SetApp(test.NewApp())
+
+	created := 0
+	RegisterRoute("orders/detail/:id", false, false, func(params RouteParams) Page {
+		created++
+		return &testPage{id: 2000 + created, title: "order " + params["id"]}
+	})
+
+	p1, err := Open("orders/detail/42", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ClosePage(p1)
+	if p1.WinTitle() != "order 42" {
+		t.Fatalf("route param not resolved: %s", p1.WinTitle())
+	}
+
+	p2, _ := Open("/orders/detail/42/", nil)
+	if p2 != p1 || created != 1 {
+		t.Fatal("opening the same route twice should reuse the window")
+	}
+
+	p3, _ := Open("orders/detail/43", nil)
+	defer ClosePage(p3)
+	if p3 == p1 {
+		t.Fatal("different route params should open a new page")
+	}
+
+	if _, err = Open("orders/unknown", nil); err == nil {
+		t.Fatal("expected error for unknown route")
+	}
+}
+
+func TestMessageBus(t *testing.T) {
+	SetApp(test.NewApp())
+
+	page := &testPage{id: 1005, title: "subscriber"}
+	ShowPage(page, false, false, nil)
+
+	var got []int
+	Subscribe(page, "orders.changed", func(id int) {
+		got = append(got, id)
+	})
+	Subscribe(page, "orders.changed", func(s string) {
+		t.Fatal("handler with mismatched type should not be called")
+	})
+
+	Publish("orders.changed", 42)
+	ClosePage(page)
+	Publish("orders.changed", 43)
+
+	if len(got) != 1 || got[0] != 42 {
+		t.Fatalf("unexpected messages: %v", got)
+	}
+}
+
+func TestMessageBus_RoutePage(t *testing.T) {
+	SetApp(test.NewApp())
+
+	var got []string
+	RegisterRoute("bus/:name", false, false, func(params RouteParams) Page {
+		page := &autoIDPage{testPage: testPage{title: params["name"]}}
+		Subscribe(page, "bus.route", func(msg string) { got = append(got, page.title+":"+msg) })
+		return page
+	})
+
+	page, err := Open("bus/a", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := &testPage{id: 0, title: "zero"}
+	ShowPage(other, false, false, nil)
+	ClosePage(other)
+
+	Publish("bus.route", "1")
+	ClosePage(page)
+	Publish("bus.route", "2")
+
+	if len(got) != 1 || got[0] != "a:1" {
+		t.Fatalf("unexpected messages: %v", got)
+	}
+}
+
+func TestWindowManager_ListPages(t *testing.T) {
+	SetApp(test.NewApp())
+
+	changed := 0
+	remove := OnPagesChanged(func(pages []PageInfo) { changed++ })
+	defer remove()
+
+	p1 := &testPage{id: 1006, title: "first"}
+	p2 := &lifecyclePage{testPage: testPage{id: 1007, title: "second"}}
+	ShowPage(p1, false, false, nil)
+	ShowPage(p2, false, false, nil)
+
+	pages := ListPages()
+	if len(pages) != 2 || pages[0].ID != 1006 || pages[1].Title != "second" {
+		t.Fatalf("unexpected pages: %v", pages)
+	}
+
+	refused := CloseAll()
+	if len(refused) != 1 || refused[0] != p2 {
+		t.Fatalf("page vetoing close should be reported: %v", refused)
+	}
+
+	p2.canClose = true
+	CloseAll()
+	if len(ListPages()) != 0 || changed != 4 {
+		t.Fatalf("unexpected state: pages=%v changed=%d", ListPages(), changed)
+	}
+}
+
+func TestWindowManager_Quit(t *testing.T) {
+	SetApp(test.NewApp())
+
+	p1 := &testPage{id: 1008, title: "main"}
+	p2 := &lifecyclePage{testPage: testPage{id: 1009, title: "editor"}}
+	ShowPage(p1, false, false, nil)
+	ShowPage(p2, false, false, nil)
+
+	QuitApp()
+	if len(ListPages()) != 2 {
+		t.Fatal("quit should be cancelled when a page vetoes closing")
+	}
+
+	p2.canClose = true
+	QuitApp()
+	if len(ListPages()) != 0 {
+		t.Fatalf("all pages should be closed on quit: %v", ListPages())
+	}
+}
+
+type orderPage struct {
+	testPage
+	orderNo string
+}
+
+func (p *orderPage) InstanceKey() string { return p.orderNo }
+func (p *orderPage) MaxInstances() int   { return 2 }
+
+func TestWindowManager_MultiInstance(t *testing.T) {
+	SetApp(test.NewApp())
+
+	o1 := &orderPage{testPage: testPage{id: 3000, title: "order"}, orderNo: "A"}
+	o2 := &orderPage{testPage: testPage{id: 3000, title: "order"}, orderNo: "B"}
+	o3 := &orderPage{testPage: testPage{id: 3000, title: "order"}, orderNo: "C"}
+	ShowPage(o1, false, false, nil)
+	ShowPage(o2, false, false, nil)
+	ShowPage(o3, false, false, nil)
+	defer CloseAll()
+
+	if GetWindows(o1) == nil || GetWindows(o2) == nil || GetWindows(o1) == GetWindows(o2) {
+		t.Fatal("each instance should get its own window")
+	}
+	if GetWindows(o3) != nil || InstanceCount(o1) != 2 {
+		t.Fatal("instance cap should be respected")
+	}
+
+	ClosePage(o1)
+	ShowPage(o3, false, false, nil)
+	if GetWindows(o3) == nil {
+		t.Fatal("closing an instance should free a slot")
+	}
+}
+
+type lifecycleOrderPage struct {
+	lifecyclePage
+	orderNo string
+}
+
+func (p *lifecycleOrderPage) InstanceKey() string { return p.orderNo }
+func (p *lifecycleOrderPage) MaxInstances() int   { return 0 }
+
+func TestWindowManager_MultiInstanceLifecycle(t *testing.T) {
+	SetApp(test.NewApp())
+
+	var placed []int
+	SetInstancePlacement(func(win fyne.Window, index int) { placed = append(placed, index) })
+	defer SetInstancePlacement(nil)
+
+	o1 := &lifecycleOrderPage{lifecyclePage: lifecyclePage{testPage: testPage{id: 3002, title: "order"}, canClose: true}, orderNo: "A"}
+	o2 := &lifecycleOrderPage{lifecyclePage: lifecyclePage{testPage: testPage{id: 3002, title: "order"}, canClose: true}, orderNo: "B"}
+	ShowPage(o1, false, false, nil)
+	ShowPage(o2, false, false, nil)
+	if len(placed) != 2 || placed[0] != 0 || placed[1] != 1 {
+		t.Fatalf("unexpected placement: %v", placed)
+	}
+
+	ClosePage(o2)
+	if n := len(o2.events); n == 0 || o2.events[n-2] != "blur" {
+		t.Fatalf("closing a focused instance should blur it: %v", o2.events)
+	}
+
+	CloseAll()
+	if n := len(o1.events); n == 0 || o1.events[n-1] != "hide" {
+		t.Fatalf("unexpected lifecycle events: %v", o1.events)
+	}
+}
+
+func TestEnableTray_NonDesktop(t *testing.T) {
+	SetApp(test.NewApp())
+
+	items := []MenuItemModel{
+		{Name: "orders", SubItems: []MenuItemModel{{Name: "list"}, {Name: "hidden", IsHidden: true}}},
+	}
+	if EnableTray(nil, items) {
+		t.Fatal("tray should not be enabled on a non-desktop app")
+	}
+	if RestoreFromTray() {
+		t.Fatal("nothing should be restored from tray")
+	}
+
+	menu := NewMenuFromModel("", items)
+	if len(menu.Items) != 1 || len(menu.Items[0].ChildMenu.Items) != 1 {
+		t.Fatal("hidden menu items should be skipped")
+	}
+}
+
+type trayPage struct {
+	lifecyclePage
+}
+
+func (p *trayPage) HideToTray() bool { return true }
+
+func TestWindowManager_TrayMode(t *testing.T) {
+	SetApp(test.NewApp())
+	SetTrayMode(true)
+	defer SetTrayMode(false)
+
+	page := &trayPage{lifecyclePage{testPage: testPage{id: 1011, title: "tray"}, canClose: true}}
+	ShowPage(page, false, false, nil)
+	defer ClosePage(page)
+
+	if !winManagerIns().hideToTray(page.WinID(), page) {
+		t.Fatal("page should be hidden to tray")
+	}
+	if GetWindows(page) == nil {
+		t.Fatal("window hidden to tray should be kept")
+	}
+	if !RestoreFromTray() || RestoreFromTray() {
+		t.Fatal("hidden window should be restored exactly once")
+	}
+
+	want := []string{"show", "focus", "blur", "hide", "show", "focus"}
+	if len(page.events) != len(want) {
+		t.Fatalf("unexpected lifecycle events: %v", page.events)
+	}
+	for i := range want {
+		if page.events[i] != want[i] {
+			t.Fatalf("unexpected lifecycle events: %v", page.events)
+		}
+	}
+}
+
+// trayApp 模拟桌面端 app，记录托盘相关的调用
+type trayApp struct {
+	fyne.App
+	menu        *fyne.Menu
+	trayWindows int
+}
+
+func (a *trayApp) SetSystemTrayMenu(menu *fyne.Menu) { a.menu = menu }
+func (a *trayApp) SetSystemTrayIcon(_ fyne.Resource) {}
+func (a *trayApp) SetSystemTrayWindow(_ fyne.Window) { a.trayWindows++ }
+
+func TestEnableTray_Desktop(t *testing.T) {
+	SetApp(test.NewApp())
+	wm := winManagerIns()
+	app := &trayApp{App: wm.GetApp()}
+	wm.mutex.Lock()
+	wm.app = app
+	wm.mutex.Unlock()
+	defer func() {
+		SetTrayMode(false)
+		wm.mutex.Lock()
+		wm.app = app.App
+		wm.mutex.Unlock()
+	}()
+
+	if !EnableTray(nil, []MenuItemModel{{Name: "orders"}}) {
+		t.Fatal("tray should be enabled on a desktop app")
+	}
+	items := app.menu.Items
+	if len(items) != 4 || items[2].Label != "显示窗口" || !items[3].IsQuit {
+		t.Fatalf("unexpected tray menu: %v", items)
+	}
+
+	page := &trayPage{lifecyclePage{testPage: testPage{id: 1012, title: "tray"}, canClose: true}}
+	ShowPage(page, false, false, nil)
+	defer ClosePage(page)
+
+	if !wm.hideToTray(page.WinID(), page) {
+		t.Fatal("page should be hidden to tray")
+	}
+	if app.trayWindows != 0 {
+		t.Fatal("SetSystemTrayWindow would replace the window's close intercept")
+	}
+
+	items[2].Action()
+	if n := len(page.events); page.events[n-2] != "show" || page.events[n-1] != "focus" {
+		t.Fatalf("restoring from the tray menu should show the page: %v", page.events)
+	}
+	if RestoreFromTray() {
+		t.Fatal("restored window should no longer be tracked")
+	}
+}
+
+func TestNotificationCenter(t *testing.T) {
+	ClearNotifications()
+	defer ClearNotifications()
+
+	first := Notify("下载", "下载完成", ToastSuccess)
+	Notify("同步", "同步失败", ToastError)
+	if n := UnreadNotifications(); n != 2 {
+		t.Fatalf("unread = %d, want 2", n)
+	}
+
+	list := Notifications()
+	if len(list) != 2 || list[0].Title != "同步" {
+		t.Fatalf("notifications should be newest first, got %+v", list)
+	}
+
+	MarkNotificationRead(first.ID)
+	if n := UnreadNotifications(); n != 1 {
+		t.Fatalf("unread after mark = %d, want 1", n)
+	}
+
+	MarkAllNotificationsRead()
+	if n := UnreadNotifications(); n != 0 {
+		t.Fatalf("unread after mark all = %d, want 0", n)
+	}
+}
+
+func TestEncryptedLocFile(t *testing.T) {
+	a := test.NewApp()
+	type cred struct {
+		User     string
+		Password string
+	}
+	const file = "myfyne.test.cred"
+	defer os.Remove(GetLocFile(a, file))
+
+	for _, pass := range []string{"secret", ""} {
+		if err := SaveToEncryptedLocFile(a, file, cred{User: "admin", Password: "123456"}, pass); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := LoadFromEncryptedLocFile[cred](a, file, pass)
+		if err != nil || got.Password != "123456" {
+			t.Fatalf("load with %q: %+v, %v", pass, got, err)
+		}
+	}
+
+	if _, err := LoadFromEncryptedLocFile[cred](a, file, "wrong"); !errors.Is(err, ErrEncryptedFileTampered) {
+		t.Fatalf("wrong key err = %v", err)
+	}
+
+	raw, _ := os.ReadFile(GetLocFile(a, file))
+	raw[len(raw)-1] ^= 0xff
+	_ = os.WriteFile(GetLocFile(a, file), raw, 0600)
+	if _, err := LoadFromEncryptedLocFile[cred](a, file, ""); !errors.Is(err, ErrEncryptedFileTampered) {
+		t.Fatalf("tampered err = %v", err)
+	}
+}
+
+func TestSettingsStore(t *testing.T) {
+	a := test.NewApp()
+	type settings struct {
+		Theme    string `json:"theme"`
+		FontSize int    `json:"fontSize"`
+	}
+	const file = "myfyne.test.settings.json"
+	path := GetLocFile(a, file)
+	defer func() {
+		for _, ext := range []string{"", ".bak", ".corrupt", ".tmp"} {
+			_ = os.Remove(path + ext)
+		}
+	}()
+
+	// 旧版本文件：没有版本信息，字段名为 dark
+	_ = os.MkdirAll(a.Storage().RootURI().Path(), 0700)
+	_ = os.WriteFile(path, []byte(`{"dark":true}`), 0600)
+
+	store := NewSettingsStore(a, file, 1, func() settings {
+		return settings{Theme: "light", FontSize: 14}
+	}).RegisterMigration(0, func(data map[string]any) (map[string]any, error) {
+		if dark, _ := data["dark"].(bool); dark {
+			data["theme"] = "dark"
+		}
+		delete(data, "dark")
+		return data, nil
+	})
+
+	v, err := store.Load()
+	if err != nil || v.Theme != "dark" || v.FontSize != 14 {
+		t.Fatalf("migrated = %+v, %v", v, err)
+	}
+
+	v.FontSize = 16
+	if err = store.Save(v); err != nil {
+		t.Fatal(err)
+	}
+
+	var corrupt error
+	store.SetOnCorrupt(func(err error) { corrupt = err })
+	_ = os.WriteFile(path, []byte(`{"version":1,"data":`), 0600)
+	v, err = store.Load()
+	if err != nil || corrupt == nil || v.Theme != "dark" {
+		t.Fatalf("recovered = %+v, %v, corrupt = %v", v, err, corrupt)
+	}
+}
+
+func TestSettingsStore_MigrationError(t *testing.T) {
+	a := test.NewApp()
+	type settings struct {
+		Theme string `json:"theme"`
+	}
+	const file = "myfyne.test.settings.migrate.json"
+	path := GetLocFile(a, file)
+	defer func() {
+		for _, ext := range []string{"", ".bak", ".corrupt", ".tmp"} {
+			_ = os.Remove(path + ext)
+		}
+	}()
+
+	_ = os.MkdirAll(a.Storage().RootURI().Path(), 0700)
+	old := []byte(`{"dark":true}`)
+	_ = os.WriteFile(path, old, 0600)
+
+	var corrupt error
+	store := NewSettingsStore[settings](a, file, 1, nil).RegisterMigration(0, func(data map[string]any) (map[string]any, error) {
+		return nil, errors.New("unsupported")
+	}).SetOnCorrupt(func(err error) { corrupt = err })
+
+	if _, err := store.Load(); !errors.Is(err, ErrSettingsMigration) {
+		t.Fatalf("err = %v, want ErrSettingsMigration", err)
+	}
+	if raw, _ := os.ReadFile(path); string(raw) != string(old) || corrupt != nil {
+		t.Fatal("migration error should leave the file untouched")
+	}
+	if _, err := os.Stat(path + ".corrupt"); err == nil {
+		t.Fatal("migration error should not be treated as corruption")
+	}
+}
+
+func TestSettingsStore_NonStruct(t *testing.T) {
+	a := test.NewApp()
+	const file = "myfyne.test.settings.list.json"
+	path := GetLocFile(a, file)
+	defer func() {
+		for _, ext := range []string{"", ".bak", ".corrupt", ".tmp"} {
+			_ = os.Remove(path + ext)
+		}
+	}()
+
+	// 旧版本文件：没有版本信息的数组
+	_ = os.MkdirAll(a.Storage().RootURI().Path(), 0700)
+	_ = os.WriteFile(path, []byte(`["a","b"]`), 0600)
+
+	var corrupt error
+	store := NewSettingsStore[[]string](a, file, 1, nil).SetOnCorrupt(func(err error) { corrupt = err })
+	v, err := store.Load()
+	if err != nil || corrupt != nil || len(*v) != 2 || (*v)[1] != "b" {
+		t.Fatalf("legacy list = %v, %v, corrupt = %v", v, err, corrupt)
+	}
+
+	*v = append(*v, "c")
+	if err = store.Save(v); err != nil {
+		t.Fatal(err)
+	}
+	if v, err = store.Load(); err != nil || corrupt != nil || len(*v) != 3 {
+		t.Fatalf("saved list = %v, %v, corrupt = %v", v, err, corrupt)
+	}
+}
+
+func TestSettingsRegistry(t *testing.T) {
+	SetApp(test.NewApp())
+	a := GetApp()
+	defer os.Remove(GetLocFile(a, settingsFile))
+
+	dark := RegisterSetting("test.dark", false, SettingOptions{Section: "外观", Label: "深色模式"})
+	size := RegisterSetting("test.fontSize", 14, SettingOptions{Section: "外观", Storage: SettingStorageFile})
+	if again := RegisterSetting("test.dark", true, SettingOptions{}); again != dark {
+		t.Fatal("registering the same key should return the existing setting")
+	}
+
+	var changed bool
+	dark.Binding().AddListener(binding.NewDataListener(func() {
+		changed = dark.Get()
+	}))
+	dark.Set(true)
+	if !changed || !a.Preferences().Bool("test.dark") {
+		t.Fatal("bool setting should notify listeners and persist to preferences")
+	}
+
+	size.Set(18)
+	values, err := settings.store.Load()
+	if err != nil || string((*values)["test.fontSize"]) != "18" {
+		t.Fatalf("file setting = %v, %v", values, err)
+	}
+
+	entry, ok := LookupSetting("test.fontSize")
+	if !ok {
+		t.Fatal("setting should be registered")
+	}
+	entry.Reset()
+	if size.Get() != 14 {
+		t.Fatalf("reset = %d, want 14", size.Get())
+	}
+}